@@ -0,0 +1,26 @@
+package appurl
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Absolute turns a path into a link for outside the site, such as a QR code
+// or an Open Graph tag. APP_URL is used when set, otherwise the request host.
+func Absolute(c *gin.Context, path string) string {
+	baseURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+
+	if baseURL == "" {
+		scheme := "http"
+
+		if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+			scheme = "https"
+		}
+
+		baseURL = scheme + "://" + c.Request.Host
+	}
+
+	return baseURL + path
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ignoredFields are never written to the audit log, either because they are
+// secrets or because they change on every save.
+var ignoredFields = map[string]bool{
+	"PasswordHash": true,
+	"CreatedAt":    true,
+	"UpdatedAt":    true,
+}
+
+// Diff compares the top level scalar fields of two values and returns the
+// ones that changed. Nested structs and slices are skipped.
+func Diff(before interface{}, after interface{}) (map[string]Change, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]Change{}
+
+	for field, value := range afterFields {
+		if !reflect.DeepEqual(beforeFields[field], value) {
+			changes[field] = Change{Before: beforeFields[field], After: value}
+		}
+	}
+
+	for field, value := range beforeFields {
+		_, ok := afterFields[field]
+
+		if !ok {
+			changes[field] = Change{Before: value, After: nil}
+		}
+	}
+
+	return changes, nil
+}
+
+func toFields(value interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+
+	if value == nil {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fields, err
+	}
+
+	var decoded map[string]interface{}
+
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		return fields, err
+	}
+
+	for field, fieldValue := range decoded {
+		if ignoredFields[field] {
+			continue
+		}
+
+		switch fieldValue.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+
+		fields[field] = fieldValue
+	}
+
+	return fields, nil
+}
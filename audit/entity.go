@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"bekasiberbagi/user"
+	"time"
+)
+
+const ACTION_CREATE = "create"
+const ACTION_UPDATE = "update"
+const ACTION_DELETE = "delete"
+
+type AuditLog struct {
+	ID         int
+	ActorID    int
+	Action     string
+	EntityType string
+	EntityID   int
+	Changes    string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	Actor      user.User `gorm:"foreignKey:ActorID"`
+}
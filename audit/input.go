@@ -0,0 +1,19 @@
+package audit
+
+type RecordInput struct {
+	ActorID    int
+	Action     string
+	EntityType string
+	EntityID   int
+	Before     interface{}
+	After      interface{}
+	IPAddress  string
+	UserAgent  string
+}
+
+type SearchInput struct {
+	ActorID    int    `form:"actor_id"`
+	Action     string `form:"action"`
+	EntityType string `form:"entity_type"`
+	EntityID   int    `form:"entity_id"`
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"bekasiberbagi/datatable"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Save(auditLog AuditLog) (AuditLog, error)
+	FindPaginated(input SearchInput, request datatable.Request) ([]AuditLog, int64, int64, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(auditLog AuditLog) (AuditLog, error) {
+	err := r.db.Create(&auditLog).Error
+
+	if err != nil {
+		return auditLog, err
+	}
+
+	return auditLog, nil
+}
+
+var auditColumns = map[string]string{
+	"created_at":  "audit_logs.created_at",
+	"actor_name":  "users.name",
+	"action":      "audit_logs.action",
+	"entity_type": "audit_logs.entity_type",
+	"changes":     "audit_logs.changes",
+	"ip_address":  "audit_logs.ip_address",
+}
+
+func (r *repository) filteredAuditLogs(input SearchInput) *gorm.DB {
+	query := r.db.Model(&AuditLog{}).Joins("LEFT JOIN users ON users.id = audit_logs.actor_id")
+
+	if input.ActorID != 0 {
+		query = query.Where("audit_logs.actor_id = ?", input.ActorID)
+	}
+
+	if input.Action != "" {
+		query = query.Where("audit_logs.action = ?", input.Action)
+	}
+
+	if input.EntityType != "" {
+		query = query.Where("audit_logs.entity_type = ?", input.EntityType)
+	}
+
+	if input.EntityID != 0 {
+		query = query.Where("audit_logs.entity_id = ?", input.EntityID)
+	}
+
+	return query
+}
+
+func (r *repository) FindPaginated(input SearchInput, request datatable.Request) ([]AuditLog, int64, int64, error) {
+	var auditLogs []AuditLog
+	var total int64
+	var filtered int64
+
+	err := r.filteredAuditLogs(input).Count(&total).Error
+	if err != nil {
+		return auditLogs, total, filtered, err
+	}
+
+	err = request.Filter(r.filteredAuditLogs(input), auditColumns).Count(&filtered).Error
+	if err != nil {
+		return auditLogs, total, filtered, err
+	}
+
+	query := request.Filter(r.filteredAuditLogs(input), auditColumns).Select("audit_logs.*").Preload("Actor")
+
+	err = request.Paginate(query, auditColumns, "audit_logs.id desc").Find(&auditLogs).Error
+	if err != nil {
+		return auditLogs, total, filtered, err
+	}
+
+	return auditLogs, total, filtered, nil
+}
+
+func (r *repository) DeleteOlderThan(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&AuditLog{})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"bekasiberbagi/datatable"
+	"encoding/json"
+	"time"
+)
+
+type Service interface {
+	Record(input RecordInput) (AuditLog, error)
+	Search(input SearchInput, request datatable.Request) ([]AuditLog, int64, int64, error)
+	Purge() (int64, error)
+}
+
+type service struct {
+	repository    Repository
+	retentionDays int
+}
+
+func NewService(repository Repository, retentionDays int) *service {
+	return &service{repository, retentionDays}
+}
+
+func (s *service) Record(input RecordInput) (AuditLog, error) {
+	changes, err := Diff(input.Before, input.After)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return AuditLog{}, err
+	}
+
+	auditLog := AuditLog{}
+	auditLog.ActorID = input.ActorID
+	auditLog.Action = input.Action
+	auditLog.EntityType = input.EntityType
+	auditLog.EntityID = input.EntityID
+	auditLog.Changes = string(encodedChanges)
+	auditLog.IPAddress = input.IPAddress
+	auditLog.UserAgent = input.UserAgent
+	auditLog.CreatedAt = time.Now()
+
+	newAuditLog, err := s.repository.Save(auditLog)
+	if err != nil {
+		return newAuditLog, err
+	}
+
+	return newAuditLog, nil
+}
+
+func (s *service) Search(input SearchInput, request datatable.Request) ([]AuditLog, int64, int64, error) {
+	return s.repository.FindPaginated(input, request)
+}
+
+// Purge removes audit logs older than the retention period. A retention of
+// zero days keeps the logs forever.
+func (s *service) Purge() (int64, error) {
+	if s.retentionDays <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().AddDate(0, 0, -s.retentionDays)
+
+	return s.repository.DeleteOlderThan(before)
+}
@@ -0,0 +1,114 @@
+package beneficiary
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// REPEATED_AID_DAYS is how far back the de-duplication report looks for a
+// household that got aid from more than one campaign.
+const REPEATED_AID_DAYS = 30
+
+const SEARCH_LIMIT = 100
+
+// Beneficiary is one household the platform delivers aid to. The NIK of the
+// head of household is never stored, only a keyed hash to recognize a second
+// registration and its last digits to tell records apart.
+type Beneficiary struct {
+	ID            int
+	Name          string
+	NIKHash       string `gorm:"column:nik_hash" json:"-"`
+	NIKLastDigits string `gorm:"column:nik_last_digits"`
+	HouseholdSize int
+	Phone         string
+	Address       string
+	Kecamatan     string
+	Kelurahan     string
+	Needs         string
+	Note          string
+	CreatedByID   int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Distributions []Distribution
+}
+
+func (b Beneficiary) MaskedNIK() string {
+	if b.NIKLastDigits == "" {
+		return "-"
+	}
+
+	return strings.Repeat("*", 12) + b.NIKLastDigits
+}
+
+func (b Beneficiary) NeedList() []string {
+	needs := []string{}
+
+	for _, need := range strings.Split(b.Needs, ",") {
+		if need != "" {
+			needs = append(needs, need)
+		}
+	}
+
+	return needs
+}
+
+// Distribution is aid handed to a beneficiary on behalf of a campaign.
+type Distribution struct {
+	ID            int
+	BeneficiaryID int
+	CampaignID    int
+	Item          string
+	Quantity      int
+	Unit          string
+	DistributedAt time.Time
+	VolunteerID   int
+	Note          string
+	RecordedByID  int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Campaign      campaign.Campaign
+	Volunteer     user.User `gorm:"foreignKey:VolunteerID"`
+}
+
+// RepeatedAid is a household that got aid from more than one campaign within
+// REPEATED_AID_DAYS.
+type RepeatedAid struct {
+	BeneficiaryID     int
+	CampaignCount     int
+	DistributionCount int
+	FirstAt           time.Time
+	LastAt            time.Time
+	Beneficiary       Beneficiary `gorm:"-"`
+}
+
+type DuplicateReport struct {
+	SharedPhone []Beneficiary
+	SharedName  []Beneficiary
+	RepeatedAid []RepeatedAid
+}
+
+func HashNIK(nik string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(nik))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func IsNIK(value string) bool {
+	if len(value) != 16 {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package beneficiary
+
+import "time"
+
+type SearchBeneficiaryInput struct {
+	Query string `form:"q"`
+}
+
+// NIK is optional, not every household has an identity card. On update an
+// empty NIK keeps the one registered before.
+type FormBeneficiaryInput struct {
+	ID            int
+	Name          string `form:"name" binding:"required"`
+	NIK           string `form:"nik" binding:"omitempty,len=16,numeric"`
+	HouseholdSize int    `form:"household_size" binding:"required,gt=0"`
+	Phone         string `form:"phone"`
+	Address       string `form:"address" binding:"required"`
+	Kecamatan     string `form:"kecamatan"`
+	Kelurahan     string `form:"kelurahan"`
+	Needs         string `form:"needs"`
+	Note          string `form:"note"`
+	EditorID      int
+	Error         error
+}
+
+type FormDistributionInput struct {
+	BeneficiaryID int
+	CampaignID    int       `form:"campaign_id" binding:"required"`
+	Item          string    `form:"item" binding:"required"`
+	Quantity      int       `form:"quantity" binding:"required,gt=0"`
+	Unit          string    `form:"unit"`
+	DistributedAt time.Time `form:"distributed_at" binding:"required" time_format:"2006-01-02"`
+	VolunteerID   int       `form:"volunteer_id"`
+	Note          string    `form:"note"`
+	RecordedByID  int
+}
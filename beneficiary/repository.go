@@ -0,0 +1,180 @@
+package beneficiary
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Save(beneficiary Beneficiary) (Beneficiary, error)
+	Update(beneficiary Beneficiary) (Beneficiary, error)
+	FindById(beneficiaryId int) (Beneficiary, error)
+	FindByNIKHash(nikHash string) (Beneficiary, error)
+	FindByIds(beneficiaryIds []int) ([]Beneficiary, error)
+	Search(query string, nikHash string, limit int) ([]Beneficiary, error)
+	FindSharedPhone() ([]Beneficiary, error)
+	FindSharedName() ([]Beneficiary, error)
+	SaveDistribution(distribution Distribution) (Distribution, error)
+	FindRepeatedAid(since time.Time) ([]RepeatedAid, error)
+	CountByCampaignID(campaignId int) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(beneficiary Beneficiary) (Beneficiary, error) {
+	err := r.db.Omit("Distributions").Create(&beneficiary).Error
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	return beneficiary, nil
+}
+
+func (r *repository) Update(beneficiary Beneficiary) (Beneficiary, error) {
+	err := r.db.Omit("Distributions").Save(&beneficiary).Error
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	return beneficiary, nil
+}
+
+func (r *repository) FindById(beneficiaryId int) (Beneficiary, error) {
+	var beneficiary Beneficiary
+
+	err := r.db.Preload("Distributions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("distributed_at desc, id desc")
+	}).Preload("Distributions.Campaign").Preload("Distributions.Volunteer").Where("id = ?", beneficiaryId).Find(&beneficiary).Error
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	return beneficiary, nil
+}
+
+func (r *repository) FindByNIKHash(nikHash string) (Beneficiary, error) {
+	var beneficiary Beneficiary
+
+	err := r.db.Where("nik_hash = ?", nikHash).Find(&beneficiary).Error
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	return beneficiary, nil
+}
+
+func (r *repository) FindByIds(beneficiaryIds []int) ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+
+	err := r.db.Where("id IN ?", beneficiaryIds).Find(&beneficiaries).Error
+
+	if err != nil {
+		return beneficiaries, err
+	}
+
+	return beneficiaries, nil
+}
+
+// Search matches a full NIK by its hash, anything else by name, phone or
+// address.
+func (r *repository) Search(query string, nikHash string, limit int) ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+
+	db := r.db
+
+	if nikHash != "" {
+		db = db.Where("nik_hash = ?", nikHash)
+	} else if query != "" {
+		like := "%" + query + "%"
+		db = db.Where("name LIKE ? OR phone LIKE ? OR address LIKE ? OR kelurahan LIKE ?", like, like, like, like)
+	}
+
+	err := db.Order("id desc").Limit(limit).Find(&beneficiaries).Error
+
+	if err != nil {
+		return beneficiaries, err
+	}
+
+	return beneficiaries, nil
+}
+
+func (r *repository) FindSharedPhone() ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+
+	sharedPhones := r.db.Model(&Beneficiary{}).Select("phone").Where("phone <> ''").Group("phone").Having("COUNT(*) > 1")
+
+	err := r.db.Where("phone IN (?)", sharedPhones).Order("phone asc, id asc").Find(&beneficiaries).Error
+
+	if err != nil {
+		return beneficiaries, err
+	}
+
+	return beneficiaries, nil
+}
+
+// FindSharedName finds households registered twice under the same name in
+// the same kelurahan, usually once with and once without a NIK.
+func (r *repository) FindSharedName() ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+
+	sharedNames := r.db.Model(&Beneficiary{}).Select("LOWER(TRIM(name)), kelurahan").Group("LOWER(TRIM(name)), kelurahan").Having("COUNT(*) > 1")
+
+	err := r.db.Where("(LOWER(TRIM(name)), kelurahan) IN (?)", sharedNames).Order("kelurahan asc, name asc, id asc").Find(&beneficiaries).Error
+
+	if err != nil {
+		return beneficiaries, err
+	}
+
+	return beneficiaries, nil
+}
+
+func (r *repository) SaveDistribution(distribution Distribution) (Distribution, error) {
+	err := r.db.Omit("Campaign", "Volunteer").Create(&distribution).Error
+
+	if err != nil {
+		return distribution, err
+	}
+
+	return distribution, nil
+}
+
+func (r *repository) FindRepeatedAid(since time.Time) ([]RepeatedAid, error) {
+	var repeatedAid []RepeatedAid
+
+	err := r.db.Model(&Distribution{}).
+		Select("beneficiary_id, COUNT(DISTINCT campaign_id) AS campaign_count, COUNT(*) AS distribution_count, MIN(distributed_at) AS first_at, MAX(distributed_at) AS last_at").
+		Where("distributed_at >= ?", since).
+		Group("beneficiary_id").
+		Having("COUNT(DISTINCT campaign_id) > 1").
+		Order("campaign_count desc, last_at desc").
+		Scan(&repeatedAid).Error
+
+	if err != nil {
+		return repeatedAid, err
+	}
+
+	return repeatedAid, nil
+}
+
+func (r *repository) CountByCampaignID(campaignId int) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&Distribution{}).Where("campaign_id = ?", campaignId).Distinct("beneficiary_id").Count(&count).Error
+
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
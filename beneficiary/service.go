@@ -0,0 +1,235 @@
+package beneficiary
+
+import (
+	"bekasiberbagi/campaign"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	SearchBeneficiaries(input SearchBeneficiaryInput) ([]Beneficiary, error)
+	GetBeneficiaryById(beneficiaryId int) (Beneficiary, error)
+	CreateFromForm(form FormBeneficiaryInput) (Beneficiary, error)
+	UpdateFromForm(form FormBeneficiaryInput) (Beneficiary, error)
+	RecordDistribution(form FormDistributionInput) (Distribution, error)
+	GetDuplicateReport() (DuplicateReport, error)
+	CountReached(campaignId int) (int, error)
+}
+
+type service struct {
+	repository         Repository
+	campaignRepository campaign.Repository
+	nikSecret          []byte
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, nikSecret []byte) *service {
+	return &service{repository, campaignRepository, nikSecret}
+}
+
+func (s *service) SearchBeneficiaries(input SearchBeneficiaryInput) ([]Beneficiary, error) {
+	query := strings.TrimSpace(input.Query)
+	nikHash := ""
+
+	if IsNIK(query) {
+		nikHash = HashNIK(query, s.nikSecret)
+	}
+
+	beneficiaries, err := s.repository.Search(query, nikHash, SEARCH_LIMIT)
+
+	if err != nil {
+		return beneficiaries, err
+	}
+
+	return beneficiaries, nil
+}
+
+func (s *service) GetBeneficiaryById(beneficiaryId int) (Beneficiary, error) {
+	beneficiary, err := s.repository.FindById(beneficiaryId)
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	if beneficiary.ID == 0 {
+		return beneficiary, errors.New("BENEFICIARY NOT FOUND")
+	}
+
+	return beneficiary, nil
+}
+
+func (s *service) CreateFromForm(form FormBeneficiaryInput) (Beneficiary, error) {
+	beneficiary := Beneficiary{}
+	beneficiary.CreatedByID = form.EditorID
+
+	err := s.fill(&beneficiary, form)
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	newBeneficiary, err := s.repository.Save(beneficiary)
+
+	if err != nil {
+		return newBeneficiary, err
+	}
+
+	return newBeneficiary, nil
+}
+
+func (s *service) UpdateFromForm(form FormBeneficiaryInput) (Beneficiary, error) {
+	beneficiary, err := s.GetBeneficiaryById(form.ID)
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	err = s.fill(&beneficiary, form)
+
+	if err != nil {
+		return beneficiary, err
+	}
+
+	updatedBeneficiary, err := s.repository.Update(beneficiary)
+
+	if err != nil {
+		return updatedBeneficiary, err
+	}
+
+	return updatedBeneficiary, nil
+}
+
+// fill copies the form and hashes a new NIK, refusing one that belongs to
+// another household already.
+func (s *service) fill(beneficiary *Beneficiary, form FormBeneficiaryInput) error {
+	if form.NIK != "" {
+		nikHash := HashNIK(form.NIK, s.nikSecret)
+
+		registered, err := s.repository.FindByNIKHash(nikHash)
+
+		if err != nil {
+			return err
+		}
+
+		if registered.ID != 0 && registered.ID != beneficiary.ID {
+			return errors.New("BENEFICIARY WITH THIS NIK IS ALREADY REGISTERED")
+		}
+
+		beneficiary.NIKHash = nikHash
+		beneficiary.NIKLastDigits = form.NIK[len(form.NIK)-4:]
+	}
+
+	beneficiary.Name = strings.TrimSpace(form.Name)
+	beneficiary.HouseholdSize = form.HouseholdSize
+	beneficiary.Phone = strings.TrimSpace(form.Phone)
+	beneficiary.Address = form.Address
+	beneficiary.Kecamatan = form.Kecamatan
+	beneficiary.Kelurahan = form.Kelurahan
+	beneficiary.Needs = campaign.NormalizeTags(form.Needs)
+	beneficiary.Note = form.Note
+
+	return nil
+}
+
+func (s *service) RecordDistribution(form FormDistributionInput) (Distribution, error) {
+	beneficiary, err := s.GetBeneficiaryById(form.BeneficiaryID)
+
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	campaignDetail, err := s.campaignRepository.FindById(form.CampaignID)
+
+	if err != nil {
+		return Distribution{}, err
+	}
+
+	if campaignDetail.ID == 0 {
+		return Distribution{}, errors.New("CAMPAIGN NOT FOUND")
+	}
+
+	if form.DistributedAt.After(time.Now()) {
+		return Distribution{}, errors.New("DISTRIBUTION DATE CANNOT BE IN THE FUTURE")
+	}
+
+	distribution := Distribution{}
+	distribution.BeneficiaryID = beneficiary.ID
+	distribution.CampaignID = campaignDetail.ID
+	distribution.Item = form.Item
+	distribution.Quantity = form.Quantity
+	distribution.Unit = form.Unit
+	distribution.DistributedAt = form.DistributedAt
+	distribution.VolunteerID = form.VolunteerID
+	distribution.Note = form.Note
+	distribution.RecordedByID = form.RecordedByID
+
+	newDistribution, err := s.repository.SaveDistribution(distribution)
+
+	if err != nil {
+		return newDistribution, err
+	}
+
+	return newDistribution, nil
+}
+
+func (s *service) GetDuplicateReport() (DuplicateReport, error) {
+	report := DuplicateReport{}
+
+	sharedPhone, err := s.repository.FindSharedPhone()
+
+	if err != nil {
+		return report, err
+	}
+
+	sharedName, err := s.repository.FindSharedName()
+
+	if err != nil {
+		return report, err
+	}
+
+	repeatedAid, err := s.repository.FindRepeatedAid(time.Now().AddDate(0, 0, -REPEATED_AID_DAYS))
+
+	if err != nil {
+		return report, err
+	}
+
+	beneficiaryIds := []int{}
+
+	for _, aid := range repeatedAid {
+		beneficiaryIds = append(beneficiaryIds, aid.BeneficiaryID)
+	}
+
+	if len(beneficiaryIds) > 0 {
+		beneficiaries, err := s.repository.FindByIds(beneficiaryIds)
+
+		if err != nil {
+			return report, err
+		}
+
+		beneficiariesById := map[int]Beneficiary{}
+
+		for _, beneficiary := range beneficiaries {
+			beneficiariesById[beneficiary.ID] = beneficiary
+		}
+
+		for i := range repeatedAid {
+			repeatedAid[i].Beneficiary = beneficiariesById[repeatedAid[i].BeneficiaryID]
+		}
+	}
+
+	report.SharedPhone = sharedPhone
+	report.SharedName = sharedName
+	report.RepeatedAid = repeatedAid
+
+	return report, nil
+}
+
+func (s *service) CountReached(campaignId int) (int, error) {
+	count, err := s.repository.CountByCampaignID(campaignId)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,45 @@
+package campaign
+
+// DetailLoader fills the parts of a campaign detail that other packages own.
+// Those packages import campaign, so their services are passed in as
+// functions instead of being called from here.
+type DetailLoader interface {
+	LoadDetails(campaign *Campaign) error
+}
+
+type detailLoader struct {
+	spending         func(campaignId int) ([]SpendingTotal, error)
+	matching         func(campaignId int) ([]MatchingTotal, error)
+	goods            func(campaignId int) ([]GoodsTotal, error)
+	beneficiaryCount func(campaignId int) (int, error)
+}
+
+func NewDetailLoader(spending func(campaignId int) ([]SpendingTotal, error), matching func(campaignId int) ([]MatchingTotal, error), goods func(campaignId int) ([]GoodsTotal, error), beneficiaryCount func(campaignId int) (int, error)) *detailLoader {
+	return &detailLoader{spending, matching, goods, beneficiaryCount}
+}
+
+func (l *detailLoader) LoadDetails(campaign *Campaign) error {
+	var err error
+
+	campaign.Spending, err = l.spending(campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.Matching, err = l.matching(campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.Goods, err = l.goods(campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.BeneficiaryCount, err = l.beneficiaryCount(campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
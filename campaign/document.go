@@ -0,0 +1,54 @@
+package campaign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const DOCUMENT_TYPE_KTP = "ktp"
+const DOCUMENT_TYPE_MEDICAL_LETTER = "medical_letter"
+const DOCUMENT_TYPE_RT_RW_LETTER = "rt_rw_letter"
+const DOCUMENT_TYPE_OTHER = "other"
+
+// DOCUMENT_STORAGE_PATH is outside of the statically served directories, so
+// documents can only be read through a signed admin URL.
+const DOCUMENT_STORAGE_PATH = "storage/documents"
+
+const DOCUMENT_URL_TTL = 15 * time.Minute
+
+var documentExtensions = map[string]bool{
+	".pdf":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+func IsAllowedDocument(fileName string) bool {
+	return documentExtensions[strings.ToLower(filepath.Ext(fileName))]
+}
+
+func signDocument(secret []byte, documentId int, expires int64) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(fmt.Sprintf("%d:%d", documentId, expires)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func documentURL(secret []byte, documentId int, expires time.Time) string {
+	return fmt.Sprintf("/web/documents/%d?expires=%d&signature=%s", documentId, expires.Unix(), signDocument(secret, documentId, expires.Unix()))
+}
+
+func validDocumentSignature(secret []byte, input GetCampaignDocumentInput, now time.Time) bool {
+	if now.Unix() > input.Expires {
+		return false
+	}
+
+	expected := signDocument(secret, input.ID, input.Expires)
+
+	return hmac.Equal([]byte(expected), []byte(input.Signature))
+}
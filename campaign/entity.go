@@ -2,11 +2,46 @@ package campaign
 
 import (
 	"bekasiberbagi/user"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/leekchan/accounting"
 )
 
+const STATUS_PENDING_REVIEW = "pending_review"
+const STATUS_APPROVED = "approved"
+const STATUS_REJECTED = "rejected"
+
+const MILESTONE_TYPE_PERCENTAGE = "percentage"
+const MILESTONE_TYPE_STRETCH_GOAL = "stretch_goal"
+
+// MILESTONE_PERCENTAGES are the shares of the goal amount that are
+// celebrated. Stretch goals add their own milestones above 100.
+var MILESTONE_PERCENTAGES = []int{25, 50, 75, 100}
+
+const DONATION_TYPE_ZAKAT_MAAL = "zakat_maal"
+const DONATION_TYPE_ZAKAT_FITRAH = "zakat_fitrah"
+const DONATION_TYPE_INFAQ = "infaq"
+const DONATION_TYPE_SEDEKAH = "sedekah"
+const DONATION_TYPE_WAKAF = "wakaf"
+
+var DONATION_TYPES = []string{DONATION_TYPE_ZAKAT_MAAL, DONATION_TYPE_ZAKAT_FITRAH, DONATION_TYPE_INFAQ, DONATION_TYPE_SEDEKAH, DONATION_TYPE_WAKAF}
+
+// DEFAULT_DONATION_TYPES are accepted by a campaign that did not declare its
+// types. Zakat and wakaf have their own rules on how the money may be spent,
+// so a campaign has to opt in to them.
+var DEFAULT_DONATION_TYPES = []string{DONATION_TYPE_INFAQ, DONATION_TYPE_SEDEKAH}
+
+var donationTypeLabels = map[string]string{
+	DONATION_TYPE_ZAKAT_MAAL:   "Zakat Maal",
+	DONATION_TYPE_ZAKAT_FITRAH: "Zakat Fitrah",
+	DONATION_TYPE_INFAQ:        "Infaq",
+	DONATION_TYPE_SEDEKAH:      "Sedekah",
+	DONATION_TYPE_WAKAF:        "Wakaf",
+}
+
 type Campaign struct {
 	ID               int
 	UserID           int
@@ -17,11 +52,33 @@ type Campaign struct {
 	BackerCount      int
 	GoalAmount       int
 	CurrentAmount    int
-	Slug             string
+	Slug             string `gorm:"uniqueIndex"`
+	CategoryID       int
+	Tags             string
+	DonationTypes    string
+	Kecamatan        string
+	Kelurahan        string
+	Address          string
+	Latitude         *float64
+	Longitude        *float64
+	Distance         *float64 `gorm:"->;-:migration"`
+	Status           string
+	ModerationReason string
+	ReviewedByID     int
+	ReviewedAt       *time.Time
+	VerifiedByID     int
+	VerifiedAt       *time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 	CampaignImages   []CampaignImage
 	User             user.User
+	Category         Category
+	StretchGoals     []CampaignStretchGoal
+	Milestones       []CampaignMilestone
+	Spending         []SpendingTotal `gorm:"-"`
+	Matching         []MatchingTotal `gorm:"-"`
+	Goods            []GoodsTotal    `gorm:"-"`
+	BeneficiaryCount int             `gorm:"-"`
 }
 
 func (c Campaign) GoalAmountFormatIDR() string {
@@ -36,6 +93,76 @@ func (c Campaign) CurrentAmountFormatIDR() string {
 	return ac.FormatMoney(c.CurrentAmount)
 }
 
+func (c Campaign) MatchedAmount() int {
+	total := 0
+
+	for _, matching := range c.Matching {
+		total += matching.Amount
+	}
+
+	return total
+}
+
+func (c Campaign) MatchedAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(c.MatchedAmount())
+}
+
+func (c Campaign) StretchGoalReached(goal CampaignStretchGoal) bool {
+	return c.CurrentAmount >= goal.Amount
+}
+
+func (c Campaign) IsLive() bool {
+	return c.Status == STATUS_APPROVED
+}
+
+// IsVisibleTo reports whether the campaign may be shown to the user. Campaigns
+// waiting for review or rejected are only shown to their organizer.
+func (c Campaign) IsVisibleTo(viewer user.User) bool {
+	return c.IsLive() || (viewer.ID != 0 && viewer.ID == c.UserID)
+}
+
+func (c Campaign) IsVerified() bool {
+	return c.VerifiedAt != nil
+}
+
+func (c Campaign) TagList() []string {
+	tags := []string{}
+
+	for _, tag := range strings.Split(c.Tags, ",") {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// PrimaryImage returns the image marked as primary, campaigns loaded with
+// all their images may list it anywhere.
+func (c Campaign) PrimaryImage() (CampaignImage, bool) {
+	for _, image := range c.CampaignImages {
+		if image.IsPrimary == 1 {
+			return image, true
+		}
+	}
+
+	return CampaignImage{}, false
+}
+
+func (c Campaign) PublicPath() string {
+	return "/c/" + c.Slug
+}
+
+func (c Campaign) FundedPercentage() float64 {
+	if c.GoalAmount == 0 {
+		return 0
+	}
+
+	return float64(c.CurrentAmount) * 100 / float64(c.GoalAmount)
+}
+
 type CampaignImage struct {
 	ID         int
 	CampaignID int
@@ -44,3 +171,253 @@ type CampaignImage struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
+
+type CampaignRevision struct {
+	ID               int
+	CampaignID       int
+	EditorID         int
+	Revision         int
+	Name             string
+	ShortDescription string
+	Description      string
+	Perks            string
+	GoalAmount       int
+	Slug             string
+	Note             string
+	CreatedAt        time.Time
+	Editor           user.User `gorm:"foreignKey:EditorID"`
+}
+
+func (r CampaignRevision) GoalAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(r.GoalAmount)
+}
+
+type RevisionChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type CampaignSlug struct {
+	ID         int
+	CampaignID int
+	Slug       string
+	CreatedAt  time.Time
+}
+
+// SpendingTotal is the amount an organizer reported as spent in one expense
+// category, filled in by the expense service for the campaign detail.
+type SpendingTotal struct {
+	Category string
+	Amount   int
+}
+
+// MatchingTotal is what one sponsor pledged to match on a campaign and how
+// much of it donations have used so far, filled in by the matching service
+// for the campaign detail.
+type MatchingTotal struct {
+	SponsorName string
+	Ratio       float64
+	CapAmount   int
+	Amount      int
+	StartDate   time.Time
+	EndDate     time.Time
+}
+
+// CampaignStretchGoal is a target above the goal amount. Goals are ordered
+// by Position and their amounts go up with it.
+type CampaignStretchGoal struct {
+	ID          int
+	CampaignID  int
+	Position    int
+	Amount      int
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (g CampaignStretchGoal) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(g.Amount)
+}
+
+// CampaignMilestone records when the current amount first crossed a
+// threshold. Threshold is the percentage of the goal for percentage
+// milestones and the stretch goal amount for stretch goal milestones, Amount
+// is always the threshold in rupiah.
+type CampaignMilestone struct {
+	ID         int
+	CampaignID int
+	Type       string
+	Threshold  int
+	Amount     int
+	Title      string
+	ReachedAt  time.Time
+	CreatedAt  time.Time
+}
+
+func (m CampaignMilestone) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(m.Amount)
+}
+
+// GoodsTotal is the progress of one item the campaign collects in kind,
+// filled in by the goods service for the campaign detail.
+type GoodsTotal struct {
+	ItemID           int
+	Name             string
+	Unit             string
+	TargetQuantity   int
+	PledgedQuantity  int
+	ReceivedQuantity int
+}
+
+func (g GoodsTotal) ReceivedPercentage() float64 {
+	if g.TargetQuantity == 0 {
+		return 0
+	}
+
+	return float64(g.ReceivedQuantity) * 100 / float64(g.TargetQuantity)
+}
+
+// CollectsMoney is false for a campaign that only collects goods.
+func (c Campaign) CollectsMoney() bool {
+	return c.GoalAmount > 0
+}
+
+type CampaignDocument struct {
+	ID           int
+	CampaignID   int
+	UserID       int
+	Type         string
+	FileName     string
+	OriginalName string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type Category struct {
+	ID          int
+	Name        string
+	Slug        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CategoryCount struct {
+	ID            int
+	Name          string
+	Slug          string
+	CampaignCount int
+}
+
+// parseCoordinate turns an optional form value into a coordinate, leaving it
+// empty when the field was not filled in.
+func parseCoordinate(value string) *float64 {
+	coordinate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+
+	if err != nil {
+		return nil
+	}
+
+	return &coordinate
+}
+
+func (c Campaign) DonationTypeList() []string {
+	if c.DonationTypes == "" {
+		return DEFAULT_DONATION_TYPES
+	}
+
+	return strings.Split(c.DonationTypes, ",")
+}
+
+func (c Campaign) DonationTypeLabels() []string {
+	labels := []string{}
+
+	for _, donationType := range c.DonationTypeList() {
+		labels = append(labels, DonationTypeLabel(donationType))
+	}
+
+	return labels
+}
+
+func (c Campaign) AcceptsDonationType(donationType string) bool {
+	for _, accepted := range c.DonationTypeList() {
+		if accepted == donationType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveDonationType falls back to sedekah when the donor did not choose a
+// type. A campaign that does not take sedekah makes the donor choose.
+func (c Campaign) ResolveDonationType(donationType string) (string, error) {
+	if donationType == "" {
+		if !c.AcceptsDonationType(DONATION_TYPE_SEDEKAH) {
+			return donationType, errors.New("DONATION TYPE IS REQUIRED FOR THIS CAMPAIGN")
+		}
+
+		return DONATION_TYPE_SEDEKAH, nil
+	}
+
+	if !c.AcceptsDonationType(donationType) {
+		return donationType, errors.New("CAMPAIGN DOES NOT ACCEPT THIS DONATION TYPE")
+	}
+
+	return donationType, nil
+}
+
+func DonationTypeLabel(donationType string) string {
+	label, ok := donationTypeLabels[donationType]
+
+	if !ok {
+		return donationType
+	}
+
+	return label
+}
+
+// JoinDonationTypes stores the declared types in the order of
+// DONATION_TYPES, dropping duplicates and unknown ones.
+func JoinDonationTypes(donationTypes []string) string {
+	joined := []string{}
+
+	for _, known := range DONATION_TYPES {
+		for _, donationType := range donationTypes {
+			if donationType == known {
+				joined = append(joined, known)
+				break
+			}
+		}
+	}
+
+	return strings.Join(joined, ",")
+}
+
+// NormalizeTags lowercases and trims free-form tags and stores them comma
+// separated without spaces, so they can be matched with FIND_IN_SET.
+func NormalizeTags(tags string) string {
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return strings.Join(normalized, ",")
+}
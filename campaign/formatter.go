@@ -1,17 +1,27 @@
 package campaign
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 type CampaignFormatter struct {
-	ID               int    `json:"id"`
-	UserID           int    `json:"user_id"`
-	Name             string `json:"name"`
-	ShortDescription string `json:"short_description"`
-	ImageURL         string `json:"image_url"`
-	GoalAmount       int    `json:"goal_amount"`
-	CurrentAmount    int    `json:"current_amount"`
-	Slug             string `json:"slug"`
-	BackerCount      int    `json:"backer_count"`
+	ID               int      `json:"id"`
+	UserID           int      `json:"user_id"`
+	Name             string   `json:"name"`
+	ShortDescription string   `json:"short_description"`
+	ImageURL         string   `json:"image_url"`
+	GoalAmount       int      `json:"goal_amount"`
+	CurrentAmount    int      `json:"current_amount"`
+	Slug             string   `json:"slug"`
+	BackerCount      int      `json:"backer_count"`
+	CategoryID       int      `json:"category_id"`
+	Category         string   `json:"category"`
+	Tags             []string `json:"tags"`
+	Kecamatan        string   `json:"kecamatan"`
+	Kelurahan        string   `json:"kelurahan"`
+	DistanceKm       *float64 `json:"distance_km,omitempty"`
+	Status           string   `json:"status"`
 }
 
 func FormatCampaign(campaign Campaign) CampaignFormatter {
@@ -24,6 +34,13 @@ func FormatCampaign(campaign Campaign) CampaignFormatter {
 	formatter.CurrentAmount = campaign.CurrentAmount
 	formatter.Slug = campaign.Slug
 	formatter.BackerCount = campaign.BackerCount
+	formatter.CategoryID = campaign.CategoryID
+	formatter.Category = campaign.Category.Name
+	formatter.Tags = campaign.TagList()
+	formatter.Kecamatan = campaign.Kecamatan
+	formatter.Kelurahan = campaign.Kelurahan
+	formatter.DistanceKm = campaign.Distance
+	formatter.Status = campaign.Status
 
 	formatter.ImageURL = ""
 
@@ -45,19 +62,91 @@ func FormatCampaigns(campaigns []Campaign) []CampaignFormatter {
 }
 
 type CampaignDetailFormatter struct {
-	Id               int                      `json:"id"`
-	Name             string                   `json:"name"`
-	ShortDescription string                   `json:"short_description"`
-	ImageURL         string                   `json:"image_url"`
-	GoalAmount       int                      `json:"goal_amount"`
-	CurrentAmount    int                      `json:"current_amount"`
-	UserId           int                      `json:"user_id"`
-	BackerCount      int                      `json:"backer_count"`
-	Slug             string                   `json:"slug"`
-	Description      string                   `json:"description"`
-	Perks            []string                 `json:"perks"`
-	User             CampaignUserFormatter    `json:"user"`
-	Images           []CampaignImageFormatter `json:"images"`
+	Id               int                            `json:"id"`
+	Name             string                         `json:"name"`
+	ShortDescription string                         `json:"short_description"`
+	ImageURL         string                         `json:"image_url"`
+	GoalAmount       int                            `json:"goal_amount"`
+	CurrentAmount    int                            `json:"current_amount"`
+	UserId           int                            `json:"user_id"`
+	BackerCount      int                            `json:"backer_count"`
+	Slug             string                         `json:"slug"`
+	Description      string                         `json:"description"`
+	Perks            []string                       `json:"perks"`
+	Category         CampaignCategoryFormatter      `json:"category"`
+	Tags             []string                       `json:"tags"`
+	DonationTypes    []string                       `json:"donation_types"`
+	Location         CampaignLocationFormatter      `json:"location"`
+	Status           string                         `json:"status"`
+	ModerationReason string                         `json:"moderation_reason"`
+	IsVerified       bool                           `json:"is_verified"`
+	Spending         CampaignSpendingFormatter      `json:"spending"`
+	MatchedAmount    int                            `json:"matched_amount"`
+	Matching         []CampaignMatchingFormatter    `json:"matching"`
+	StretchGoals     []CampaignStretchGoalFormatter `json:"stretch_goals"`
+	Milestones       []CampaignMilestoneFormatter   `json:"milestones"`
+	Goods            []CampaignGoodsFormatter       `json:"goods"`
+	BeneficiaryCount int                            `json:"beneficiary_count"`
+	User             CampaignUserFormatter          `json:"user"`
+	Images           []CampaignImageFormatter       `json:"images"`
+}
+
+type CampaignCategoryFormatter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type CampaignLocationFormatter struct {
+	Kecamatan string   `json:"kecamatan"`
+	Kelurahan string   `json:"kelurahan"`
+	Address   string   `json:"address"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+type CampaignSpendingFormatter struct {
+	Total      int                                 `json:"total"`
+	Categories []CampaignSpendingCategoryFormatter `json:"categories"`
+}
+
+type CampaignSpendingCategoryFormatter struct {
+	Category string `json:"category"`
+	Amount   int    `json:"amount"`
+}
+
+type CampaignMatchingFormatter struct {
+	SponsorName string    `json:"sponsor_name"`
+	Ratio       float64   `json:"ratio"`
+	CapAmount   int       `json:"cap_amount"`
+	Amount      int       `json:"amount"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+}
+
+type CampaignStretchGoalFormatter struct {
+	Position    int    `json:"position"`
+	Amount      int    `json:"amount"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	IsReached   bool   `json:"is_reached"`
+}
+
+type CampaignMilestoneFormatter struct {
+	Type      string    `json:"type"`
+	Threshold int       `json:"threshold"`
+	Amount    int       `json:"amount"`
+	Title     string    `json:"title"`
+	ReachedAt time.Time `json:"reached_at"`
+}
+
+type CampaignGoodsFormatter struct {
+	ItemID           int    `json:"item_id"`
+	Name             string `json:"name"`
+	Unit             string `json:"unit"`
+	TargetQuantity   int    `json:"target_quantity"`
+	PledgedQuantity  int    `json:"pledged_quantity"`
+	ReceivedQuantity int    `json:"received_quantity"`
 }
 
 type CampaignUserFormatter struct {
@@ -82,6 +171,73 @@ func FormatCampaignDetail(campaign Campaign) CampaignDetailFormatter {
 	formatter.Slug = campaign.Slug
 	formatter.UserId = campaign.UserID
 	formatter.BackerCount = campaign.BackerCount
+	formatter.Status = campaign.Status
+	formatter.ModerationReason = campaign.ModerationReason
+	formatter.IsVerified = campaign.IsVerified()
+
+	spending := CampaignSpendingFormatter{Categories: []CampaignSpendingCategoryFormatter{}}
+
+	for _, total := range campaign.Spending {
+		spending.Total += total.Amount
+		spending.Categories = append(spending.Categories, CampaignSpendingCategoryFormatter{
+			Category: total.Category,
+			Amount:   total.Amount,
+		})
+	}
+
+	formatter.Spending = spending
+
+	formatter.MatchedAmount = campaign.MatchedAmount()
+	formatter.Matching = []CampaignMatchingFormatter{}
+
+	for _, matching := range campaign.Matching {
+		formatter.Matching = append(formatter.Matching, CampaignMatchingFormatter{
+			SponsorName: matching.SponsorName,
+			Ratio:       matching.Ratio,
+			CapAmount:   matching.CapAmount,
+			Amount:      matching.Amount,
+			StartDate:   matching.StartDate,
+			EndDate:     matching.EndDate,
+		})
+	}
+
+	formatter.BeneficiaryCount = campaign.BeneficiaryCount
+	formatter.Goods = []CampaignGoodsFormatter{}
+
+	for _, goods := range campaign.Goods {
+		formatter.Goods = append(formatter.Goods, CampaignGoodsFormatter{
+			ItemID:           goods.ItemID,
+			Name:             goods.Name,
+			Unit:             goods.Unit,
+			TargetQuantity:   goods.TargetQuantity,
+			PledgedQuantity:  goods.PledgedQuantity,
+			ReceivedQuantity: goods.ReceivedQuantity,
+		})
+	}
+
+	formatter.StretchGoals = []CampaignStretchGoalFormatter{}
+
+	for _, stretchGoal := range campaign.StretchGoals {
+		formatter.StretchGoals = append(formatter.StretchGoals, CampaignStretchGoalFormatter{
+			Position:    stretchGoal.Position,
+			Amount:      stretchGoal.Amount,
+			Title:       stretchGoal.Title,
+			Description: stretchGoal.Description,
+			IsReached:   campaign.StretchGoalReached(stretchGoal),
+		})
+	}
+
+	formatter.Milestones = []CampaignMilestoneFormatter{}
+
+	for _, milestone := range campaign.Milestones {
+		formatter.Milestones = append(formatter.Milestones, CampaignMilestoneFormatter{
+			Type:      milestone.Type,
+			Threshold: milestone.Threshold,
+			Amount:    milestone.Amount,
+			Title:     milestone.Title,
+			ReachedAt: milestone.ReachedAt,
+		})
+	}
 
 	formatter.ImageURL = ""
 
@@ -97,6 +253,23 @@ func FormatCampaignDetail(campaign Campaign) CampaignDetailFormatter {
 
 	formatter.Perks = perks
 
+	formatter.Category = CampaignCategoryFormatter{
+		ID:   campaign.Category.ID,
+		Name: campaign.Category.Name,
+		Slug: campaign.Category.Slug,
+	}
+
+	formatter.Tags = campaign.TagList()
+	formatter.DonationTypes = campaign.DonationTypeList()
+
+	formatter.Location = CampaignLocationFormatter{
+		Kecamatan: campaign.Kecamatan,
+		Kelurahan: campaign.Kelurahan,
+		Address:   campaign.Address,
+		Latitude:  campaign.Latitude,
+		Longitude: campaign.Longitude,
+	}
+
 	var campaignUserFormatter CampaignUserFormatter
 	campaignUserFormatter.Name = campaign.User.Name
 	campaignUserFormatter.ImageURL = campaign.User.AvatarFileName
@@ -122,3 +295,71 @@ func FormatCampaignDetail(campaign Campaign) CampaignDetailFormatter {
 
 	return formatter
 }
+
+type CampaignRevisionFormatter struct {
+	ID               int       `json:"id"`
+	Revision         int       `json:"revision"`
+	Name             string    `json:"name"`
+	ShortDescription string    `json:"short_description"`
+	Description      string    `json:"description"`
+	Perks            []string  `json:"perks"`
+	GoalAmount       int       `json:"goal_amount"`
+	Slug             string    `json:"slug"`
+	Note             string    `json:"note"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func FormatCampaignRevision(revision CampaignRevision) CampaignRevisionFormatter {
+	formatter := CampaignRevisionFormatter{}
+	formatter.ID = revision.ID
+	formatter.Revision = revision.Revision
+	formatter.Name = revision.Name
+	formatter.ShortDescription = revision.ShortDescription
+	formatter.Description = revision.Description
+	formatter.GoalAmount = revision.GoalAmount
+	formatter.Slug = revision.Slug
+	formatter.Note = revision.Note
+	formatter.CreatedAt = revision.CreatedAt
+
+	var perks []string
+
+	for _, perk := range strings.Split(revision.Perks, ",") {
+		perks = append(perks, strings.Trim(perk, " "))
+	}
+
+	formatter.Perks = perks
+
+	return formatter
+}
+
+func FormatCampaignRevisions(revisions []CampaignRevision) []CampaignRevisionFormatter {
+	revisionsFormatter := []CampaignRevisionFormatter{}
+
+	for _, revision := range revisions {
+		revisionsFormatter = append(revisionsFormatter, FormatCampaignRevision(revision))
+	}
+
+	return revisionsFormatter
+}
+
+type CategoryFormatter struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	CampaignCount int    `json:"campaign_count"`
+}
+
+func FormatCategoryCounts(categories []CategoryCount) []CategoryFormatter {
+	categoriesFormatter := []CategoryFormatter{}
+
+	for _, category := range categories {
+		categoriesFormatter = append(categoriesFormatter, CategoryFormatter{
+			ID:            category.ID,
+			Name:          category.Name,
+			Slug:          category.Slug,
+			CampaignCount: category.CampaignCount,
+		})
+	}
+
+	return categoriesFormatter
+}
@@ -1,17 +1,44 @@
 package campaign
 
-import "bekasiberbagi/user"
+import (
+	"bekasiberbagi/user"
+	"time"
+)
 
 type GetCampaignDetailInput struct {
 	ID int `uri:"id" binding:"required"`
 }
 
+type GetCampaignsInput struct {
+	UserID     int       `form:"user_id"`
+	CategoryID int       `form:"category_id"`
+	Tag        string    `form:"tag"`
+	Kecamatan  string    `form:"kecamatan"`
+	Kelurahan  string    `form:"kelurahan"`
+	Query      string    `form:"q"`
+	Viewer     user.User `form:"-"`
+}
+
+type GetNearbyCampaignsInput struct {
+	Latitude  *float64 `form:"lat" binding:"required,latitude"`
+	Longitude *float64 `form:"lng" binding:"required,longitude"`
+	RadiusKm  float64  `form:"radius_km" binding:"omitempty,gt=0,lte=100"`
+}
+
 type CreateCampaignInput struct {
-	Name             string `json:"name" binding:"required"`
-	ShortDescription string `json:"short_description" binding:"required"`
-	Description      string `json:"description" binding:"required"`
-	GoalAmount       int    `json:"goal_amount" binding:"required"`
-	Perks            string `json:"perks"`
+	Name             string   `json:"name" binding:"required"`
+	ShortDescription string   `json:"short_description" binding:"required"`
+	Description      string   `json:"description" binding:"required"`
+	GoalAmount       int      `json:"goal_amount" binding:"gte=0"`
+	Perks            string   `json:"perks"`
+	CategoryID       int      `json:"category_id"`
+	Tags             string   `json:"tags"`
+	DonationTypes    []string `json:"donation_types" binding:"dive,oneof=zakat_maal zakat_fitrah infaq sedekah wakaf"`
+	Kecamatan        string   `json:"kecamatan"`
+	Kelurahan        string   `json:"kelurahan"`
+	Address          string   `json:"address"`
+	Latitude         *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude        *float64 `json:"longitude" binding:"omitempty,longitude"`
 	User             user.User
 }
 
@@ -23,14 +50,34 @@ type CreateCampaignImageInput struct {
 
 type FormCreateCampaignInput struct {
 	ID               int
-	Name             string `form:"name" binding:"required"`
-	ShortDescription string `form:"short_description" binding:"required"`
-	Description      string `form:"description" binding:"required"`
-	GoalAmount       int    `form:"goal_amount" binding:"required"`
-	Perks            string `form:"perks" binding:"required"`
-	UserID           int    `form:"user_id" binding:"required"`
+	Name             string   `form:"name" binding:"required"`
+	ShortDescription string   `form:"short_description" binding:"required"`
+	Description      string   `form:"description" binding:"required"`
+	GoalAmount       int      `form:"goal_amount" binding:"gte=0"`
+	Perks            string   `form:"perks" binding:"required"`
+	UserID           int      `form:"user_id" binding:"required"`
+	CategoryID       int      `form:"category_id"`
+	Tags             string   `form:"tags"`
+	DonationTypes    []string `form:"donation_types" binding:"dive,oneof=zakat_maal zakat_fitrah infaq sedekah wakaf"`
+	Kecamatan        string   `form:"kecamatan"`
+	Kelurahan        string   `form:"kelurahan"`
+	Address          string   `form:"address"`
+	Latitude         string   `form:"latitude" binding:"omitempty,latitude"`
+	Longitude        string   `form:"longitude" binding:"omitempty,longitude"`
+	EditorID         int
 	Error            error
 	Users            []user.User
+	Categories       []Category
+}
+
+func (f FormCreateCampaignInput) HasDonationType(donationType string) bool {
+	for _, selected := range f.DonationTypes {
+		if selected == donationType {
+			return true
+		}
+	}
+
+	return false
 }
 
 type FormUpdateImage struct {
@@ -39,3 +86,68 @@ type FormUpdateImage struct {
 	Image string `file:"campaign_image"`
 	Error error
 }
+
+type ExportCampaignInput struct {
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02"`
+	Format    string    `form:"format"`
+}
+
+type GetCampaignRevisionInput struct {
+	ID         int `uri:"id" binding:"required"`
+	RevisionID int `uri:"revision_id" binding:"required"`
+}
+
+type GetCampaignBySlugInput struct {
+	Slug string `uri:"slug" binding:"required"`
+}
+
+type GetCampaignQRCodeInput struct {
+	Format      string `form:"format"`
+	Size        int    `form:"size"`
+	UtmSource   string `form:"utm_source"`
+	UtmMedium   string `form:"utm_medium"`
+	UtmCampaign string `form:"utm_campaign"`
+}
+
+type FormCategoryInput struct {
+	ID          int
+	Name        string `form:"name" binding:"required"`
+	Description string `form:"description"`
+	Error       error
+}
+
+type FormModerationInput struct {
+	ID         int
+	Reason     string `form:"reason"`
+	ReviewerID int
+}
+
+type CreateCampaignDocumentInput struct {
+	CampaignID int
+	Type       string `form:"type" binding:"required,oneof=ktp medical_letter rt_rw_letter other"`
+	User       user.User
+}
+
+type GetCampaignDocumentInput struct {
+	ID        int
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+type FormVerifyCampaignInput struct {
+	ID         int
+	Verified   bool `form:"verified"`
+	VerifierID int
+}
+
+type StretchGoalInput struct {
+	Amount      int    `json:"amount" binding:"required,gt=0"`
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+type UpdateStretchGoalsInput struct {
+	StretchGoals []StretchGoalInput `json:"stretch_goals" binding:"dive"`
+	User         user.User
+}
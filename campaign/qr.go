@@ -0,0 +1,41 @@
+package campaign
+
+import (
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+func GenerateQRCodePNG(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// GenerateQRCodeSVG draws every dark module of the QR code as a square, so
+// the code stays sharp at any print size.
+func GenerateQRCodeSVG(content string, size int) (string, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&builder, `<rect width="%d" height="%d" fill="#ffffff"/>`, modules, modules)
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&builder, `<rect x="%d" y="%d" width="1" height="1" fill="#000000"/>`, x, y)
+			}
+		}
+	}
+
+	builder.WriteString(`</svg>`)
+
+	return builder.String(), nil
+}
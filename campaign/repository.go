@@ -1,6 +1,16 @@
 package campaign
 
-import "gorm.io/gorm"
+import (
+	"bekasiberbagi/datatable"
+	"errors"
+	"math"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const MYSQL_DUPLICATE_ENTRY = 1062
 
 type Repository interface {
 	FindAll() ([]Campaign, error)
@@ -11,8 +21,44 @@ type Repository interface {
 	CreateImage(campaignImage CampaignImage) (CampaignImage, error)
 	MarkImageToNonPrimary(campaignId int) (bool, error)
 	FindAllWithImages() ([]Campaign, error)
+	FindInBatches(input ExportCampaignInput, batchSize int, fn func(campaigns []Campaign) error) error
+	FindTopByAmount(limit int) ([]Campaign, error)
+	FindTopByBackers(limit int) ([]Campaign, error)
+	FindPaginated(request datatable.Request) ([]Campaign, int64, int64, error)
+	SaveRevision(revision CampaignRevision) (CampaignRevision, error)
+	FindRevisionsByCampaignID(campaignId int) ([]CampaignRevision, error)
+	FindRevisionById(revisionId int) (CampaignRevision, error)
+	SaveOriginalRevision(revision CampaignRevision) (CampaignRevision, error)
+	FindBySlug(slug string) (Campaign, error)
+	FindSlugHistory(slug string) (CampaignSlug, error)
+	SaveSlugHistory(campaignSlug CampaignSlug) (CampaignSlug, error)
+	SlugExists(slug string, exceptCampaignId int) (bool, error)
+	FindFiltered(input GetCampaignsInput) ([]Campaign, error)
+	FindByStatus(status string) ([]Campaign, error)
+	FindNearby(latitude float64, longitude float64, radiusKm float64) ([]Campaign, error)
+	SaveDocument(document CampaignDocument) (CampaignDocument, error)
+	FindDocumentsByCampaignID(campaignId int) ([]CampaignDocument, error)
+	FindDocumentById(documentId int) (CampaignDocument, error)
+	FindAllCategories() ([]Category, error)
+	FindCategoriesWithCount(status string) ([]CategoryCount, error)
+	FindCategoryById(categoryId int) (Category, error)
+	CategorySlugExists(slug string, exceptCategoryId int) (bool, error)
+	SaveCategory(category Category) (Category, error)
+	UpdateCategory(category Category) (Category, error)
+	DeleteCategory(category Category) error
+	CountByCategoryID(categoryId int) (int64, error)
+	ReplaceStretchGoals(campaignId int, stretchGoals []CampaignStretchGoal) error
+	SaveMilestone(milestone CampaignMilestone) (CampaignMilestone, error)
+	MilestoneExists(campaignId int, milestoneType string, threshold int) (bool, error)
+	ApproveUnmoderated() (int64, error)
+	FindDuplicateSlugs() ([]Campaign, error)
+	UpdateSlug(campaignId int, slug string) error
+	CreateSlugIndex() error
 }
 
+// kmPerDegree is the length of one degree of latitude on the earth's surface.
+const kmPerDegree = 111.045
+
 type repository struct {
 	db *gorm.DB
 }
@@ -57,10 +103,100 @@ func (r *repository) FindByUserID(userId int) ([]Campaign, error) {
 	return campaigns, nil
 }
 
+func (r *repository) FindFiltered(input GetCampaignsInput) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	query := r.db.Preload("CampaignImages", "campaign_images.is_primary = 1").Preload("Category")
+
+	// Organizers listing their own campaigns see them in every status, for
+	// example to read why one was rejected.
+	if input.UserID == 0 || input.UserID != input.Viewer.ID {
+		query = query.Where("status = ?", STATUS_APPROVED)
+	}
+
+	if input.UserID != 0 {
+		query = query.Where("user_id = ?", input.UserID)
+	}
+
+	if input.CategoryID != 0 {
+		query = query.Where("category_id = ?", input.CategoryID)
+	}
+
+	if input.Tag != "" {
+		query = query.Where("FIND_IN_SET(?, tags)", NormalizeTags(input.Tag))
+	}
+
+	if input.Kecamatan != "" {
+		query = query.Where("kecamatan = ?", input.Kecamatan)
+	}
+
+	if input.Kelurahan != "" {
+		query = query.Where("kelurahan = ?", input.Kelurahan)
+	}
+
+	if input.Query != "" {
+		like := "%" + input.Query + "%"
+		query = query.Where("name LIKE ? OR short_description LIKE ?", like, like)
+	}
+
+	err := query.Order("id desc").Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+func (r *repository) FindByStatus(status string) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	err := r.db.Where("status = ?", status).Preload("CampaignImages", "campaign_images.is_primary = 1").Preload("User").Preload("Category").Order("updated_at asc").Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+// FindNearby narrows the candidates with a latitude/longitude bounding box
+// first, then computes the haversine distance in km for the remaining rows and
+// keeps the ones inside the radius. The radius is checked on a derived table
+// because filtering on a column alias with HAVING only works in MySQL.
+func (r *repository) FindNearby(latitude float64, longitude float64, radiusKm float64) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	latDelta := radiusKm / kmPerDegree
+	lngDelta := radiusKm / (kmPerDegree * math.Cos(latitude*math.Pi/180))
+
+	distance := "6371 * 2 * ASIN(SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + " +
+		"COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)))"
+
+	candidates := r.db.Model(&Campaign{}).
+		Select("campaigns.*, "+distance+" AS distance", latitude, latitude, longitude).
+		Where("status = ?", STATUS_APPROVED).
+		Where("latitude BETWEEN ? AND ?", latitude-latDelta, latitude+latDelta).
+		Where("longitude BETWEEN ? AND ?", longitude-lngDelta, longitude+lngDelta)
+
+	err := r.db.Table("(?) AS campaigns", candidates).
+		Where("distance <= ?", radiusKm).
+		Order("distance asc").
+		Preload("CampaignImages", "campaign_images.is_primary = 1").
+		Preload("Category").
+		Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
 func (r *repository) FindById(campaignId int) (Campaign, error) {
 	var campaign Campaign
 
-	err := r.db.Preload("CampaignImages").Preload("User").Where("id = ?", campaignId).Find(&campaign).Error
+	err := r.withProgress(r.db).Preload("CampaignImages").Preload("User").Preload("Category").Where("id = ?", campaignId).Find(&campaign).Error
 
 	if err != nil {
 		return campaign, err
@@ -108,3 +244,460 @@ func (r *repository) MarkImageToNonPrimary(campaignId int) (bool, error) {
 
 	return true, nil
 }
+
+func (r *repository) FindInBatches(input ExportCampaignInput, batchSize int, fn func(campaigns []Campaign) error) error {
+	var campaigns []Campaign
+
+	query := r.db.Preload("User")
+
+	if !input.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", input.StartDate)
+	}
+
+	if !input.EndDate.IsZero() {
+		query = query.Where("created_at < ?", input.EndDate.AddDate(0, 0, 1))
+	}
+
+	err := query.FindInBatches(&campaigns, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(campaigns)
+	}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) FindTopByAmount(limit int) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	err := r.db.Where("status = ?", STATUS_APPROVED).Order("current_amount desc").Limit(limit).Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+func (r *repository) FindTopByBackers(limit int) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	err := r.db.Where("status = ?", STATUS_APPROVED).Order("backer_count desc").Limit(limit).Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+var campaignColumns = map[string]string{
+	"name":              "name",
+	"short_description": "short_description",
+	"goal_amount":       "goal_amount",
+	"current_amount":    "current_amount",
+}
+
+func (r *repository) FindPaginated(request datatable.Request) ([]Campaign, int64, int64, error) {
+	var campaigns []Campaign
+	var total int64
+	var filtered int64
+
+	err := r.db.Model(&Campaign{}).Count(&total).Error
+	if err != nil {
+		return campaigns, total, filtered, err
+	}
+
+	err = request.Filter(r.db.Model(&Campaign{}), campaignColumns).Count(&filtered).Error
+	if err != nil {
+		return campaigns, total, filtered, err
+	}
+
+	query := request.Filter(r.db.Model(&Campaign{}), campaignColumns).Preload("CampaignImages", "campaign_images.is_primary = 1").Preload("Category")
+
+	err = request.Paginate(query, campaignColumns, "id desc").Find(&campaigns).Error
+	if err != nil {
+		return campaigns, total, filtered, err
+	}
+
+	return campaigns, total, filtered, nil
+}
+
+// SaveRevision stores the revision under the next number of its campaign.
+// The campaign row stays locked until the insert, so edits saved at the same
+// time are numbered one after the other.
+func (r *repository) SaveRevision(revision CampaignRevision) (CampaignRevision, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		latest, err := lockLatestRevision(tx, revision.CampaignID)
+
+		if err != nil {
+			return err
+		}
+
+		revision.Revision = latest + 1
+
+		return tx.Create(&revision).Error
+	})
+
+	if err != nil {
+		return revision, err
+	}
+
+	return revision, nil
+}
+
+// SaveOriginalRevision stores the revision as number 1 unless the campaign
+// already has revisions, then nothing is stored and the ID stays 0.
+func (r *repository) SaveOriginalRevision(revision CampaignRevision) (CampaignRevision, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		latest, err := lockLatestRevision(tx, revision.CampaignID)
+
+		if err != nil || latest > 0 {
+			return err
+		}
+
+		revision.Revision = 1
+
+		return tx.Create(&revision).Error
+	})
+
+	if err != nil {
+		return revision, err
+	}
+
+	return revision, nil
+}
+
+// lockLatestRevision locks the campaign for the rest of the transaction and
+// returns its latest revision number.
+func lockLatestRevision(tx *gorm.DB, campaignId int) (int, error) {
+	var campaign Campaign
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", campaignId).Find(&campaign).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int
+
+	err = tx.Model(&CampaignRevision{}).Select("COALESCE(MAX(revision), 0)").Where("campaign_id = ?", campaignId).Scan(&latest).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	return latest, nil
+}
+
+// isDuplicateKey reports an insert rejected by a unique index.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == MYSQL_DUPLICATE_ENTRY
+}
+
+func (r *repository) FindRevisionsByCampaignID(campaignId int) ([]CampaignRevision, error) {
+	var revisions []CampaignRevision
+
+	err := r.db.Preload("Editor").Where("campaign_id = ?", campaignId).Order("revision desc").Find(&revisions).Error
+
+	if err != nil {
+		return revisions, err
+	}
+
+	return revisions, nil
+}
+
+func (r *repository) FindRevisionById(revisionId int) (CampaignRevision, error) {
+	var revision CampaignRevision
+
+	err := r.db.Preload("Editor").Where("id = ?", revisionId).Find(&revision).Error
+
+	if err != nil {
+		return revision, err
+	}
+
+	return revision, nil
+}
+
+func (r *repository) FindBySlug(slug string) (Campaign, error) {
+	var campaign Campaign
+
+	err := r.withProgress(r.db).Preload("CampaignImages").Preload("User").Where("slug = ?", slug).Find(&campaign).Error
+
+	if err != nil {
+		return campaign, err
+	}
+
+	return campaign, nil
+}
+
+func (r *repository) FindSlugHistory(slug string) (CampaignSlug, error) {
+	var campaignSlug CampaignSlug
+
+	err := r.db.Where("slug = ?", slug).Order("id desc").Limit(1).Find(&campaignSlug).Error
+
+	if err != nil {
+		return campaignSlug, err
+	}
+
+	return campaignSlug, nil
+}
+
+func (r *repository) SaveSlugHistory(campaignSlug CampaignSlug) (CampaignSlug, error) {
+	err := r.db.Create(&campaignSlug).Error
+
+	if err != nil {
+		return campaignSlug, err
+	}
+
+	return campaignSlug, nil
+}
+
+func (r *repository) SlugExists(slug string, exceptCampaignId int) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&Campaign{}).Where("slug = ? AND id <> ?", slug, exceptCampaignId).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	err = r.db.Model(&CampaignSlug{}).Where("slug = ? AND campaign_id <> ?", slug, exceptCampaignId).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) SaveDocument(document CampaignDocument) (CampaignDocument, error) {
+	err := r.db.Create(&document).Error
+
+	if err != nil {
+		return document, err
+	}
+
+	return document, nil
+}
+
+func (r *repository) FindDocumentsByCampaignID(campaignId int) ([]CampaignDocument, error) {
+	var documents []CampaignDocument
+
+	err := r.db.Where("campaign_id = ?", campaignId).Order("id asc").Find(&documents).Error
+
+	if err != nil {
+		return documents, err
+	}
+
+	return documents, nil
+}
+
+func (r *repository) FindDocumentById(documentId int) (CampaignDocument, error) {
+	var document CampaignDocument
+
+	err := r.db.Where("id = ?", documentId).Find(&document).Error
+
+	if err != nil {
+		return document, err
+	}
+
+	return document, nil
+}
+
+func (r *repository) FindAllCategories() ([]Category, error) {
+	var categories []Category
+
+	err := r.db.Order("name asc").Find(&categories).Error
+
+	if err != nil {
+		return categories, err
+	}
+
+	return categories, nil
+}
+
+// FindCategoriesWithCount counts the campaigns of every category, only those
+// with the given status unless it is empty. The status belongs in the join so
+// categories without such campaigns are still listed with zero.
+func (r *repository) FindCategoriesWithCount(status string) ([]CategoryCount, error) {
+	var categories []CategoryCount
+
+	query := r.db.Table("categories")
+
+	if status != "" {
+		query = query.Joins("LEFT JOIN campaigns ON campaigns.category_id = categories.id AND campaigns.status = ?", status)
+	} else {
+		query = query.Joins("LEFT JOIN campaigns ON campaigns.category_id = categories.id")
+	}
+
+	err := query.
+		Select("categories.id, categories.name, categories.slug, COUNT(campaigns.id) AS campaign_count").
+		Group("categories.id, categories.name, categories.slug").
+		Order("categories.name asc").
+		Scan(&categories).Error
+
+	if err != nil {
+		return categories, err
+	}
+
+	return categories, nil
+}
+
+func (r *repository) FindCategoryById(categoryId int) (Category, error) {
+	var category Category
+
+	err := r.db.Where("id = ?", categoryId).Find(&category).Error
+
+	if err != nil {
+		return category, err
+	}
+
+	return category, nil
+}
+
+func (r *repository) CategorySlugExists(slug string, exceptCategoryId int) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&Category{}).Where("slug = ? AND id <> ?", slug, exceptCategoryId).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) SaveCategory(category Category) (Category, error) {
+	err := r.db.Create(&category).Error
+
+	if err != nil {
+		return category, err
+	}
+
+	return category, nil
+}
+
+func (r *repository) UpdateCategory(category Category) (Category, error) {
+	err := r.db.Save(&category).Error
+
+	if err != nil {
+		return category, err
+	}
+
+	return category, nil
+}
+
+func (r *repository) DeleteCategory(category Category) error {
+	return r.db.Delete(&category).Error
+}
+
+func (r *repository) CountByCategoryID(categoryId int) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&Campaign{}).Where("category_id = ?", categoryId).Count(&count).Error
+
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// withProgress preloads the stretch goals and reached milestones in the order
+// they are shown.
+func (r *repository) withProgress(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("StretchGoals", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc")
+		}).
+		Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+			return db.Order("reached_at asc, id asc")
+		})
+}
+
+func (r *repository) ReplaceStretchGoals(campaignId int, stretchGoals []CampaignStretchGoal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("campaign_id = ?", campaignId).Delete(&CampaignStretchGoal{}).Error
+
+		if err != nil {
+			return err
+		}
+
+		if len(stretchGoals) == 0 {
+			return nil
+		}
+
+		return tx.Create(&stretchGoals).Error
+	})
+}
+
+func (r *repository) SaveMilestone(milestone CampaignMilestone) (CampaignMilestone, error) {
+	err := r.db.Create(&milestone).Error
+
+	if err != nil {
+		return milestone, err
+	}
+
+	return milestone, nil
+}
+
+func (r *repository) MilestoneExists(campaignId int, milestoneType string, threshold int) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&CampaignMilestone{}).Where("campaign_id = ? AND type = ? AND threshold = ?", campaignId, milestoneType, threshold).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ApproveUnmoderated approves campaigns created before moderation existed,
+// they have no status and were public until then.
+func (r *repository) ApproveUnmoderated() (int64, error) {
+	result := r.db.Model(&Campaign{}).Where("status = '' OR status IS NULL").Update("status", STATUS_APPROVED)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// FindDuplicateSlugs returns every campaign sharing its slug with an older
+// one, slugs were not unique before the index on them.
+func (r *repository) FindDuplicateSlugs() ([]Campaign, error) {
+	var campaigns []Campaign
+
+	err := r.db.Where("EXISTS (SELECT 1 FROM campaigns AS older WHERE older.slug = campaigns.slug AND older.id < campaigns.id)").Order("id asc").Find(&campaigns).Error
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+func (r *repository) UpdateSlug(campaignId int, slug string) error {
+	return r.db.Model(&Campaign{}).Where("id = ?", campaignId).Update("slug", slug).Error
+}
+
+// CreateSlugIndex adds the unique index on the slug when the table does not
+// have it yet.
+func (r *repository) CreateSlugIndex() error {
+	if r.db.Migrator().HasIndex(&Campaign{}, "Slug") {
+		return nil
+	}
+
+	return r.db.Migrator().CreateIndex(&Campaign{}, "Slug")
+}
@@ -0,0 +1,208 @@
+package campaign
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SQLite has no trigonometric functions by default, the nearby query needs
+// the ones MySQL provides.
+func init() {
+	sql.Register("sqlite3_math", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			functions := map[string]func(float64) float64{
+				"ASIN":    math.Asin,
+				"SQRT":    math.Sqrt,
+				"SIN":     math.Sin,
+				"COS":     math.Cos,
+				"RADIANS": func(degrees float64) float64 { return degrees * math.Pi / 180 },
+			}
+
+			for name, function := range functions {
+				function := function
+
+				err := conn.RegisterFunc(name, func(value interface{}) float64 {
+					return function(toFloat(value))
+				}, true)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return conn.RegisterFunc("POWER", func(base interface{}, exponent interface{}) float64 {
+				return math.Pow(toFloat(base), toFloat(exponent))
+			}, true)
+		},
+	})
+}
+
+// toFloat accepts both kinds of numbers SQLite passes to a function, an
+// exponent written as 2 arrives as an integer.
+func toFloat(value interface{}) float64 {
+	switch number := value.(type) {
+	case int64:
+		return float64(number)
+	case float64:
+		return number
+	}
+
+	return 0
+}
+
+func newTestRepository(t *testing.T) (*repository, *gorm.DB) {
+	db, err := gorm.Open(&sqlite.Dialector{DriverName: "sqlite3_math", DSN: "file::memory:"}, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every connection to :memory: is a new empty database.
+	sqlDB.SetMaxOpenConns(1)
+
+	err = db.AutoMigrate(&Campaign{}, &CampaignImage{}, &Category{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This gorm version creates the computed distance column although it is
+	// marked to be left out of migrations, the real table has none.
+	err = db.Exec("ALTER TABLE campaigns DROP COLUMN distance").Error
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewRepository(db), db
+}
+
+// offset returns the point northKm north and eastKm east of the given one.
+func offset(latitude float64, longitude float64, northKm float64, eastKm float64) (*float64, *float64) {
+	newLatitude := latitude + northKm/kmPerDegree
+	newLongitude := longitude + eastKm/(kmPerDegree*math.Cos(latitude*math.Pi/180))
+
+	return &newLatitude, &newLongitude
+}
+
+func createNearbyFixture(t *testing.T, db *gorm.DB, name string, status string, latitude *float64, longitude *float64) {
+	campaign := Campaign{Name: name, Slug: name, Status: status, Latitude: latitude, Longitude: longitude}
+
+	err := db.Create(&campaign).Error
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func names(campaigns []Campaign) []string {
+	result := []string{}
+
+	for _, campaign := range campaigns {
+		result = append(result, campaign.Name)
+	}
+
+	return result
+}
+
+func TestFindNearby(t *testing.T) {
+	// Alun-alun Bekasi.
+	latitude := -6.2383
+	longitude := 106.9756
+
+	tests := []struct {
+		name     string
+		status   string
+		northKm  float64
+		eastKm   float64
+		expected bool
+	}{
+		{name: "one-km-north", status: STATUS_APPROVED, northKm: 1, expected: true},
+		{name: "next-door", status: STATUS_APPROVED, eastKm: 0.2, expected: true},
+		{name: "four-km-south-west", status: STATUS_APPROVED, northKm: -3, eastKm: -3, expected: true},
+		{name: "box-corner", status: STATUS_APPROVED, northKm: 4.5, eastKm: 4.5, expected: false},
+		{name: "other-city", status: STATUS_APPROVED, northKm: 30, expected: false},
+		{name: "pending", status: STATUS_PENDING_REVIEW, eastKm: 0.5, expected: false},
+		{name: "rejected", status: STATUS_REJECTED, northKm: 0.5, expected: false},
+	}
+
+	repository, db := newTestRepository(t)
+
+	for _, test := range tests {
+		campaignLatitude, campaignLongitude := offset(latitude, longitude, test.northKm, test.eastKm)
+		createNearbyFixture(t, db, test.name, test.status, campaignLatitude, campaignLongitude)
+	}
+
+	createNearbyFixture(t, db, "no-location", STATUS_APPROVED, nil, nil)
+
+	campaigns, err := repository.FindNearby(latitude, longitude, 5)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"next-door", "one-km-north", "four-km-south-west"}
+	actual := names(campaigns)
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v in this order, got %v", expected, actual)
+		}
+	}
+
+	for _, campaign := range campaigns {
+		if campaign.Distance == nil || *campaign.Distance > 5 {
+			t.Errorf("%s: expected a distance within 5 km, got %v", campaign.Name, campaign.Distance)
+		}
+	}
+
+	if math.Abs(*campaigns[1].Distance-1) > 0.05 {
+		t.Errorf("expected one-km-north at about 1 km, got %.3f km", *campaigns[1].Distance)
+	}
+}
+
+// The bounding box must widen in longitude away from the equator, otherwise
+// campaigns due east or west inside the radius are cut off before the
+// distance is computed.
+func TestFindNearbyBoundingBoxAtHighLatitude(t *testing.T) {
+	latitude := 60.0
+	longitude := 10.0
+
+	repository, db := newTestRepository(t)
+
+	eastLatitude, eastLongitude := offset(latitude, longitude, 0, 9)
+	createNearbyFixture(t, db, "nine-km-east", STATUS_APPROVED, eastLatitude, eastLongitude)
+
+	westLatitude, westLongitude := offset(latitude, longitude, 0, -11)
+	createNearbyFixture(t, db, "eleven-km-west", STATUS_APPROVED, westLatitude, westLongitude)
+
+	campaigns, err := repository.FindNearby(latitude, longitude, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := names(campaigns)
+
+	if len(actual) != 1 || actual[0] != "nine-km-east" {
+		t.Fatalf("expected [nine-km-east], got %v", actual)
+	}
+}
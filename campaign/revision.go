@@ -0,0 +1,43 @@
+package campaign
+
+import (
+	"strconv"
+	"time"
+)
+
+func NewRevision(campaign Campaign, editorID int, note string) CampaignRevision {
+	revision := CampaignRevision{}
+	revision.CampaignID = campaign.ID
+	revision.EditorID = editorID
+	revision.Name = campaign.Name
+	revision.ShortDescription = campaign.ShortDescription
+	revision.Description = campaign.Description
+	revision.Perks = campaign.Perks
+	revision.GoalAmount = campaign.GoalAmount
+	revision.Slug = campaign.Slug
+	revision.Note = note
+	revision.CreatedAt = time.Now()
+
+	return revision
+}
+
+func DiffRevisions(from CampaignRevision, to CampaignRevision) []RevisionChange {
+	fields := []RevisionChange{
+		{"Name", from.Name, to.Name},
+		{"Short Description", from.ShortDescription, to.ShortDescription},
+		{"Description", from.Description, to.Description},
+		{"Perks", from.Perks, to.Perks},
+		{"Goal Amount", strconv.Itoa(from.GoalAmount), strconv.Itoa(to.GoalAmount)},
+		{"Slug", from.Slug, to.Slug},
+	}
+
+	changes := []RevisionChange{}
+
+	for _, field := range fields {
+		if field.Before != field.After {
+			changes = append(changes, field)
+		}
+	}
+
+	return changes
+}
@@ -1,16 +1,21 @@
 package campaign
 
 import (
+	"bekasiberbagi/datatable"
+	"bekasiberbagi/user"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gosimple/slug"
 )
 
 type Service interface {
-	GetCampaigns(userId int) ([]Campaign, error)
+	GetCampaigns(input GetCampaignsInput) ([]Campaign, error)
+	GetNearbyCampaigns(input GetNearbyCampaignsInput) ([]Campaign, error)
 	GetCampaignById(input GetCampaignDetailInput) (Campaign, error)
+	GetVisibleCampaign(input GetCampaignDetailInput, viewer user.User) (Campaign, error)
 	CreateCampaign(input CreateCampaignInput) (Campaign, error)
 	UpdateCampaign(inputUri GetCampaignDetailInput, input CreateCampaignInput) (Campaign, error)
 
@@ -21,23 +26,66 @@ type Service interface {
 
 	GetCampaignByIntId(id int) (Campaign, error)
 	UploadImageFromForm(input FormUpdateImage, fileLocation string) (CampaignImage, error)
+	ExportCampaigns(input ExportCampaignInput, fn func(campaigns []Campaign) error) error
+	GetCampaignsPaginated(request datatable.Request) ([]Campaign, int64, int64, error)
+	GetRevisions(campaignId int) ([]CampaignRevision, error)
+	GetRevision(input GetCampaignRevisionInput) (CampaignRevision, error)
+	RollbackToRevision(input GetCampaignRevisionInput, editorID int) (Campaign, error)
+	GetCampaignBySlug(input GetCampaignBySlugInput) (Campaign, bool, error)
+	GetPendingCampaigns() ([]Campaign, error)
+	ApproveCampaign(input FormModerationInput) (Campaign, error)
+	RejectCampaign(input FormModerationInput) (Campaign, error)
+	CreateDocument(input CreateCampaignDocumentInput, fileLocation string, originalName string) (CampaignDocument, error)
+	GetDocuments(campaignId int) ([]CampaignDocument, error)
+	DocumentURL(document CampaignDocument) string
+	GetSignedDocument(input GetCampaignDocumentInput) (CampaignDocument, error)
+	SetVerified(input FormVerifyCampaignInput) (Campaign, error)
+	GetCategories() ([]Category, error)
+	GetCategoriesWithCount(status string) ([]CategoryCount, error)
+	GetCategoryById(id int) (Category, error)
+	CreateCategory(form FormCategoryInput) (Category, error)
+	UpdateCategory(form FormCategoryInput) (Category, error)
+	DeleteCategory(id int) (Category, error)
+	UpdateStretchGoals(inputUri GetCampaignDetailInput, input UpdateStretchGoalsInput) (Campaign, error)
+	BackfillStatus() (int64, error)
+	EnsureUniqueSlugs() (int, error)
 }
 
+const DEFAULT_NEARBY_RADIUS_KM = 10
+
+// ErrCampaignNotFound lets handlers tell a missing campaign from a failed
+// lookup.
+var ErrCampaignNotFound = errors.New("CAMPAIGN NOT FOUND")
+
 type service struct {
-	repository Repository
+	repository       Repository
+	reapprovalOnEdit bool
+	documentSecret   []byte
 }
 
-func NewService(repository Repository) *service {
-	return &service{repository: repository}
+func NewService(repository Repository, reapprovalOnEdit bool, documentSecret []byte) *service {
+	return &service{repository: repository, reapprovalOnEdit: reapprovalOnEdit, documentSecret: documentSecret}
 }
 
-func (s *service) GetCampaigns(userId int) ([]Campaign, error) {
-	campaigns, err := s.repository.FindAll()
+func (s *service) GetCampaigns(input GetCampaignsInput) ([]Campaign, error) {
+	campaigns, err := s.repository.FindFiltered(input)
 
-	if userId != 0 {
-		campaigns, err = s.repository.FindByUserID(userId)
+	if err != nil {
+		return campaigns, err
 	}
 
+	return campaigns, nil
+}
+
+func (s *service) GetNearbyCampaigns(input GetNearbyCampaignsInput) ([]Campaign, error) {
+	radiusKm := input.RadiusKm
+
+	if radiusKm == 0 {
+		radiusKm = DEFAULT_NEARBY_RADIUS_KM
+	}
+
+	campaigns, err := s.repository.FindNearby(*input.Latitude, *input.Longitude, radiusKm)
+
 	if err != nil {
 		return campaigns, err
 	}
@@ -55,6 +103,22 @@ func (s *service) GetCampaignById(input GetCampaignDetailInput) (Campaign, error
 	return campaign, nil
 }
 
+// GetVisibleCampaign reports campaigns the viewer may not see as not found,
+// so guests cannot tell a rejected campaign from one that does not exist.
+func (s *service) GetVisibleCampaign(input GetCampaignDetailInput, viewer user.User) (Campaign, error) {
+	campaign, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	if campaign.ID == 0 || !campaign.IsVisibleTo(viewer) {
+		return Campaign{}, ErrCampaignNotFound
+	}
+
+	return campaign, nil
+}
+
 func (s *service) GetCampaignByIntId(id int) (Campaign, error) {
 	campaign, err := s.repository.FindById(id)
 
@@ -72,15 +136,27 @@ func (s *service) CreateCampaign(input CreateCampaignInput) (Campaign, error) {
 	campaign.Description = input.Description
 	campaign.GoalAmount = input.GoalAmount
 	campaign.Perks = input.Perks
+	campaign.CategoryID = input.CategoryID
+	campaign.Tags = NormalizeTags(input.Tags)
+	campaign.DonationTypes = JoinDonationTypes(input.DonationTypes)
+	campaign.Kecamatan = input.Kecamatan
+	campaign.Kelurahan = input.Kelurahan
+	campaign.Address = input.Address
+	campaign.Latitude = input.Latitude
+	campaign.Longitude = input.Longitude
 	campaign.UserID = input.User.ID
 	campaign.BackerCount = 0
+	campaign.Status = STATUS_PENDING_REVIEW
 	campaign.CreatedAt = time.Now()
 	campaign.UpdatedAt = time.Now()
 
-	userSlug := fmt.Sprintf("%s %d", input.Name, input.User.ID)
-	campaign.Slug = slug.Make(userSlug)
+	campaign, err := s.saveWithSlug(campaign)
 
-	campaign, err := s.repository.Save(campaign)
+	if err != nil {
+		return campaign, err
+	}
+
+	_, err = s.saveRevision(campaign, input.User.ID, "")
 
 	if err != nil {
 		return campaign, err
@@ -100,18 +176,35 @@ func (s *service) UpdateCampaign(inputUri GetCampaignDetailInput, input CreateCa
 		return singleCampaign, errors.New("USER UNAUTHORIZED TO EDIT THIS CAMPAIGN")
 	}
 
-	updatedCampaign := Campaign{}
-	updatedCampaign.ID = inputUri.ID
+	err = s.saveOriginalRevision(singleCampaign)
+
+	if err != nil {
+		return singleCampaign, err
+	}
+
+	// Copy the stored row so fields the organizer cannot edit, such as the
+	// raised amount, moderation and verification, survive the update.
+	updatedCampaign := singleCampaign
 	updatedCampaign.Name = input.Name
 	updatedCampaign.ShortDescription = input.ShortDescription
 	updatedCampaign.Description = input.Description
 	updatedCampaign.GoalAmount = input.GoalAmount
 	updatedCampaign.Perks = input.Perks
-	updatedCampaign.UserID = input.User.ID
-	updatedCampaign.BackerCount = 0
-	updatedCampaign.CreatedAt = singleCampaign.CreatedAt
+	updatedCampaign.CategoryID = input.CategoryID
+	updatedCampaign.Tags = NormalizeTags(input.Tags)
+	updatedCampaign.DonationTypes = JoinDonationTypes(input.DonationTypes)
+	updatedCampaign.Kecamatan = input.Kecamatan
+	updatedCampaign.Kelurahan = input.Kelurahan
+	updatedCampaign.Address = input.Address
+	updatedCampaign.Latitude = input.Latitude
+	updatedCampaign.Longitude = input.Longitude
 	updatedCampaign.UpdatedAt = time.Now()
-	updatedCampaign.Slug = singleCampaign.Slug
+
+	updatedCampaign.Category = Category{}
+
+	if s.requiresReview(singleCampaign, updatedCampaign) {
+		updatedCampaign.Status = STATUS_PENDING_REVIEW
+	}
 
 	resultCampaign, err := s.repository.Update(updatedCampaign)
 
@@ -119,6 +212,12 @@ func (s *service) UpdateCampaign(inputUri GetCampaignDetailInput, input CreateCa
 		return resultCampaign, err
 	}
 
+	_, err = s.saveRevision(resultCampaign, input.User.ID, "")
+
+	if err != nil {
+		return resultCampaign, err
+	}
+
 	return resultCampaign, nil
 }
 
@@ -213,15 +312,28 @@ func (s *service) CreateFromForm(form FormCreateCampaignInput) (Campaign, error)
 	campaign.Description = form.Description
 	campaign.GoalAmount = form.GoalAmount
 	campaign.Perks = form.Perks
+	campaign.CategoryID = form.CategoryID
+	campaign.Tags = NormalizeTags(form.Tags)
+	campaign.DonationTypes = JoinDonationTypes(form.DonationTypes)
+	campaign.Kecamatan = form.Kecamatan
+	campaign.Kelurahan = form.Kelurahan
+	campaign.Address = form.Address
+	campaign.Latitude = parseCoordinate(form.Latitude)
+	campaign.Longitude = parseCoordinate(form.Longitude)
 	campaign.UserID = form.UserID
 	campaign.BackerCount = 0
+	campaign.Status = STATUS_APPROVED
+	campaign.ReviewedByID = form.EditorID
 	campaign.CreatedAt = time.Now()
 	campaign.UpdatedAt = time.Now()
 
-	userSlug := fmt.Sprintf("%s %d", form.Name, form.UserID)
-	campaign.Slug = slug.Make(userSlug)
+	campaign, err := s.saveWithSlug(campaign)
+
+	if err != nil {
+		return campaign, err
+	}
 
-	campaign, err := s.repository.Save(campaign)
+	_, err = s.saveRevision(campaign, form.EditorID, "")
 
 	if err != nil {
 		return campaign, err
@@ -237,17 +349,44 @@ func (s *service) UpdateFromForm(form FormCreateCampaignInput) (Campaign, error)
 		return campaign, err
 	}
 
+	err = s.saveOriginalRevision(campaign)
+
+	if err != nil {
+		return campaign, err
+	}
+
 	campaign.ID = form.ID
 	campaign.Name = form.Name
 	campaign.ShortDescription = form.ShortDescription
 	campaign.Description = form.Description
 	campaign.GoalAmount = form.GoalAmount
 	campaign.Perks = form.Perks
+	campaign.CategoryID = form.CategoryID
+	campaign.Tags = NormalizeTags(form.Tags)
+	campaign.DonationTypes = JoinDonationTypes(form.DonationTypes)
+	campaign.Kecamatan = form.Kecamatan
+	campaign.Kelurahan = form.Kelurahan
+	campaign.Address = form.Address
+	campaign.Latitude = parseCoordinate(form.Latitude)
+	campaign.Longitude = parseCoordinate(form.Longitude)
 	campaign.UserID = form.UserID
 	campaign.UpdatedAt = time.Now()
 
-	userSlug := fmt.Sprintf("%s %d", form.Name, form.UserID)
-	campaign.Slug = slug.Make(userSlug)
+	// The preloaded category would otherwise be saved back as the
+	// association and override the newly selected category id.
+	campaign.Category = Category{}
+
+	newSlug, err := s.generateSlug(form.Name, form.UserID, campaign.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	err = s.changeSlug(&campaign, newSlug)
+
+	if err != nil {
+		return campaign, err
+	}
 
 	updatedCampaign, err := s.repository.Update(campaign)
 
@@ -255,5 +394,588 @@ func (s *service) UpdateFromForm(form FormCreateCampaignInput) (Campaign, error)
 		return updatedCampaign, err
 	}
 
+	_, err = s.saveRevision(updatedCampaign, form.EditorID, "")
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
 	return updatedCampaign, nil
 }
+
+func (s *service) ExportCampaigns(input ExportCampaignInput, fn func(campaigns []Campaign) error) error {
+	return s.repository.FindInBatches(input, 500, fn)
+}
+
+func (s *service) GetCampaignsPaginated(request datatable.Request) ([]Campaign, int64, int64, error) {
+	return s.repository.FindPaginated(request)
+}
+
+func (s *service) saveRevision(campaign Campaign, editorID int, note string) (CampaignRevision, error) {
+	return s.repository.SaveRevision(NewRevision(campaign, editorID, note))
+}
+
+// saveOriginalRevision keeps a campaign created before revisions were stored
+// as revision 1 ahead of its first edit, so the history starts from what
+// donors first saw.
+func (s *service) saveOriginalRevision(campaign Campaign) error {
+	revision := NewRevision(campaign, campaign.UserID, "Original version")
+	revision.CreatedAt = campaign.UpdatedAt
+
+	_, err := s.repository.SaveOriginalRevision(revision)
+
+	return err
+}
+
+func (s *service) GetRevisions(campaignId int) ([]CampaignRevision, error) {
+	revisions, err := s.repository.FindRevisionsByCampaignID(campaignId)
+
+	if err != nil {
+		return revisions, err
+	}
+
+	return revisions, nil
+}
+
+func (s *service) GetRevision(input GetCampaignRevisionInput) (CampaignRevision, error) {
+	revision, err := s.repository.FindRevisionById(input.RevisionID)
+
+	if err != nil {
+		return revision, err
+	}
+
+	if revision.ID == 0 || revision.CampaignID != input.ID {
+		return revision, errors.New("REVISION NOT FOUND")
+	}
+
+	return revision, nil
+}
+
+func (s *service) RollbackToRevision(input GetCampaignRevisionInput, editorID int) (Campaign, error) {
+	revision, err := s.GetRevision(input)
+
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	campaign, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	campaign.Name = revision.Name
+	campaign.ShortDescription = revision.ShortDescription
+	campaign.Description = revision.Description
+	campaign.Perks = revision.Perks
+	campaign.GoalAmount = revision.GoalAmount
+	campaign.UpdatedAt = time.Now()
+
+	revisionSlug := revision.Slug
+
+	exists, err := s.repository.SlugExists(revisionSlug, campaign.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	if exists {
+		revisionSlug = campaign.Slug
+	}
+
+	err = s.changeSlug(&campaign, revisionSlug)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	updatedCampaign, err := s.repository.Update(campaign)
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	_, err = s.saveRevision(updatedCampaign, editorID, fmt.Sprintf("Rollback to revision %d", revision.Revision))
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	return updatedCampaign, nil
+}
+
+// saveWithSlug inserts the campaign under a free slug. Another campaign can
+// take the slug between the check and the insert, the unique index on the
+// slug turns that insert down and the next free slug is tried.
+func (s *service) saveWithSlug(campaign Campaign) (Campaign, error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		newSlug, err := s.generateSlug(campaign.Name, campaign.UserID, 0)
+
+		if err != nil {
+			return campaign, err
+		}
+
+		campaign.Slug = newSlug
+
+		newCampaign, err := s.repository.Save(campaign)
+
+		if !isDuplicateKey(err) {
+			return newCampaign, err
+		}
+	}
+
+	return campaign, errors.New("CAMPAIGN SLUG IS ALREADY TAKEN")
+}
+
+// generateSlug builds the slug from the campaign name and owner, adding a
+// numeric suffix until it is not used by another campaign, current or past.
+func (s *service) generateSlug(name string, userId int, campaignId int) (string, error) {
+	baseSlug := slug.Make(fmt.Sprintf("%s %d", name, userId))
+	candidate := baseSlug
+
+	for i := 2; ; i++ {
+		exists, err := s.repository.SlugExists(candidate, campaignId)
+
+		if err != nil {
+			return candidate, err
+		}
+
+		if !exists {
+			return candidate, nil
+		}
+
+		candidate = fmt.Sprintf("%s-%d", baseSlug, i)
+	}
+}
+
+// changeSlug keeps the old slug in the history so shared links keep working.
+func (s *service) changeSlug(campaign *Campaign, newSlug string) error {
+	if campaign.Slug == "" || campaign.Slug == newSlug {
+		campaign.Slug = newSlug
+		return nil
+	}
+
+	campaignSlug := CampaignSlug{}
+	campaignSlug.CampaignID = campaign.ID
+	campaignSlug.Slug = campaign.Slug
+	campaignSlug.CreatedAt = time.Now()
+
+	_, err := s.repository.SaveSlugHistory(campaignSlug)
+
+	if err != nil {
+		return err
+	}
+
+	campaign.Slug = newSlug
+
+	return nil
+}
+
+// GetCampaignBySlug also resolves old slugs. The returned bool is true when
+// the slug is an old one and the caller should redirect to campaign.Slug.
+func (s *service) GetCampaignBySlug(input GetCampaignBySlugInput) (Campaign, bool, error) {
+	campaign, err := s.repository.FindBySlug(input.Slug)
+
+	if err != nil {
+		return campaign, false, err
+	}
+
+	if campaign.ID != 0 {
+		if !campaign.IsLive() {
+			return Campaign{}, false, ErrCampaignNotFound
+		}
+
+		return campaign, false, nil
+	}
+
+	campaignSlug, err := s.repository.FindSlugHistory(input.Slug)
+
+	if err != nil {
+		return campaign, false, err
+	}
+
+	if campaignSlug.ID == 0 {
+		return campaign, false, ErrCampaignNotFound
+	}
+
+	campaign, err = s.repository.FindById(campaignSlug.CampaignID)
+
+	if err != nil {
+		return campaign, false, err
+	}
+
+	if campaign.ID == 0 || !campaign.IsLive() {
+		return Campaign{}, false, ErrCampaignNotFound
+	}
+
+	return campaign, true, nil
+}
+
+func (s *service) GetCategories() ([]Category, error) {
+	categories, err := s.repository.FindAllCategories()
+
+	if err != nil {
+		return categories, err
+	}
+
+	return categories, nil
+}
+
+func (s *service) GetCategoriesWithCount(status string) ([]CategoryCount, error) {
+	categories, err := s.repository.FindCategoriesWithCount(status)
+
+	if err != nil {
+		return categories, err
+	}
+
+	return categories, nil
+}
+
+func (s *service) GetCategoryById(id int) (Category, error) {
+	category, err := s.repository.FindCategoryById(id)
+
+	if err != nil {
+		return category, err
+	}
+
+	if category.ID == 0 {
+		return category, errors.New("CATEGORY NOT FOUND")
+	}
+
+	return category, nil
+}
+
+func (s *service) CreateCategory(form FormCategoryInput) (Category, error) {
+	category := Category{}
+	category.Name = form.Name
+	category.Slug = slug.Make(form.Name)
+	category.Description = form.Description
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	exists, err := s.repository.CategorySlugExists(category.Slug, 0)
+
+	if err != nil {
+		return category, err
+	}
+
+	if exists {
+		return category, errors.New("CATEGORY ALREADY EXISTS")
+	}
+
+	newCategory, err := s.repository.SaveCategory(category)
+
+	if err != nil {
+		return newCategory, err
+	}
+
+	return newCategory, nil
+}
+
+func (s *service) UpdateCategory(form FormCategoryInput) (Category, error) {
+	category, err := s.GetCategoryById(form.ID)
+
+	if err != nil {
+		return category, err
+	}
+
+	category.Name = form.Name
+	category.Slug = slug.Make(form.Name)
+	category.Description = form.Description
+	category.UpdatedAt = time.Now()
+
+	exists, err := s.repository.CategorySlugExists(category.Slug, category.ID)
+
+	if err != nil {
+		return category, err
+	}
+
+	if exists {
+		return category, errors.New("CATEGORY ALREADY EXISTS")
+	}
+
+	updatedCategory, err := s.repository.UpdateCategory(category)
+
+	if err != nil {
+		return updatedCategory, err
+	}
+
+	return updatedCategory, nil
+}
+
+func (s *service) DeleteCategory(id int) (Category, error) {
+	category, err := s.GetCategoryById(id)
+
+	if err != nil {
+		return category, err
+	}
+
+	count, err := s.repository.CountByCategoryID(category.ID)
+
+	if err != nil {
+		return category, err
+	}
+
+	if count > 0 {
+		return category, errors.New("CATEGORY STILL HAS CAMPAIGNS")
+	}
+
+	err = s.repository.DeleteCategory(category)
+
+	if err != nil {
+		return category, err
+	}
+
+	return category, nil
+}
+
+// requiresReview decides whether an organizer edit sends the campaign back to
+// the moderation queue. Rejected campaigns are resubmitted by any edit, live
+// campaigns only when key fields change and re-approval is switched on.
+func (s *service) requiresReview(before Campaign, after Campaign) bool {
+	if before.Status == STATUS_REJECTED {
+		return true
+	}
+
+	if before.Status != STATUS_APPROVED || !s.reapprovalOnEdit {
+		return false
+	}
+
+	return before.GoalAmount != after.GoalAmount || before.Description != after.Description
+}
+
+func (s *service) GetPendingCampaigns() ([]Campaign, error) {
+	campaigns, err := s.repository.FindByStatus(STATUS_PENDING_REVIEW)
+
+	if err != nil {
+		return campaigns, err
+	}
+
+	return campaigns, nil
+}
+
+func (s *service) ApproveCampaign(input FormModerationInput) (Campaign, error) {
+	return s.moderate(input, STATUS_APPROVED)
+}
+
+func (s *service) RejectCampaign(input FormModerationInput) (Campaign, error) {
+	if strings.TrimSpace(input.Reason) == "" {
+		return Campaign{}, errors.New("REJECTION REASON IS REQUIRED")
+	}
+
+	return s.moderate(input, STATUS_REJECTED)
+}
+
+func (s *service) moderate(input FormModerationInput, status string) (Campaign, error) {
+	campaign, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	if campaign.ID == 0 {
+		return campaign, ErrCampaignNotFound
+	}
+
+	if campaign.Status != STATUS_PENDING_REVIEW {
+		return campaign, errors.New("CAMPAIGN IS NOT PENDING REVIEW")
+	}
+
+	now := time.Now()
+
+	campaign.Status = status
+	campaign.ModerationReason = strings.TrimSpace(input.Reason)
+	campaign.ReviewedByID = input.ReviewerID
+	campaign.ReviewedAt = &now
+	campaign.UpdatedAt = now
+
+	updatedCampaign, err := s.repository.Update(campaign)
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	return updatedCampaign, nil
+}
+
+func (s *service) CreateDocument(input CreateCampaignDocumentInput, fileLocation string, originalName string) (CampaignDocument, error) {
+	campaign, err := s.repository.FindById(input.CampaignID)
+
+	if err != nil {
+		return CampaignDocument{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return CampaignDocument{}, errors.New("USER UNAUTHORIZED TO UPLOAD DOCUMENT THIS CAMPAIGN")
+	}
+
+	document := CampaignDocument{}
+	document.CampaignID = campaign.ID
+	document.UserID = input.User.ID
+	document.Type = input.Type
+	document.FileName = fileLocation
+	document.OriginalName = originalName
+	document.CreatedAt = time.Now()
+	document.UpdatedAt = time.Now()
+
+	newDocument, err := s.repository.SaveDocument(document)
+
+	if err != nil {
+		return newDocument, err
+	}
+
+	return newDocument, nil
+}
+
+func (s *service) GetDocuments(campaignId int) ([]CampaignDocument, error) {
+	documents, err := s.repository.FindDocumentsByCampaignID(campaignId)
+
+	if err != nil {
+		return documents, err
+	}
+
+	return documents, nil
+}
+
+func (s *service) DocumentURL(document CampaignDocument) string {
+	return documentURL(s.documentSecret, document.ID, time.Now().Add(DOCUMENT_URL_TTL))
+}
+
+func (s *service) GetSignedDocument(input GetCampaignDocumentInput) (CampaignDocument, error) {
+	if !validDocumentSignature(s.documentSecret, input, time.Now()) {
+		return CampaignDocument{}, errors.New("DOCUMENT LINK IS INVALID OR EXPIRED")
+	}
+
+	document, err := s.repository.FindDocumentById(input.ID)
+
+	if err != nil {
+		return document, err
+	}
+
+	if document.ID == 0 {
+		return document, errors.New("DOCUMENT NOT FOUND")
+	}
+
+	return document, nil
+}
+
+func (s *service) SetVerified(input FormVerifyCampaignInput) (Campaign, error) {
+	campaign, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	if campaign.ID == 0 {
+		return campaign, ErrCampaignNotFound
+	}
+
+	if input.Verified {
+		documents, err := s.repository.FindDocumentsByCampaignID(campaign.ID)
+
+		if err != nil {
+			return campaign, err
+		}
+
+		if len(documents) == 0 {
+			return campaign, errors.New("CAMPAIGN HAS NO VERIFICATION DOCUMENTS")
+		}
+
+		now := time.Now()
+		campaign.VerifiedAt = &now
+		campaign.VerifiedByID = input.VerifierID
+	} else {
+		campaign.VerifiedAt = nil
+		campaign.VerifiedByID = 0
+	}
+
+	campaign.UpdatedAt = time.Now()
+
+	updatedCampaign, err := s.repository.Update(campaign)
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	return updatedCampaign, nil
+}
+
+// UpdateStretchGoals replaces the stretch goals of a campaign with the given
+// list, which has to start above the goal amount and go up from there.
+func (s *service) UpdateStretchGoals(inputUri GetCampaignDetailInput, input UpdateStretchGoalsInput) (Campaign, error) {
+	campaign, err := s.repository.FindById(inputUri.ID)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return campaign, errors.New("USER UNAUTHORIZED TO EDIT THIS CAMPAIGN")
+	}
+
+	stretchGoals := []CampaignStretchGoal{}
+	previousAmount := campaign.GoalAmount
+
+	for i, stretchGoalInput := range input.StretchGoals {
+		if stretchGoalInput.Amount <= previousAmount {
+			return campaign, errors.New("STRETCH GOALS MUST BE ABOVE THE GOAL AND THE PREVIOUS STRETCH GOAL")
+		}
+
+		stretchGoal := CampaignStretchGoal{}
+		stretchGoal.CampaignID = campaign.ID
+		stretchGoal.Position = i + 1
+		stretchGoal.Amount = stretchGoalInput.Amount
+		stretchGoal.Title = stretchGoalInput.Title
+		stretchGoal.Description = stretchGoalInput.Description
+
+		stretchGoals = append(stretchGoals, stretchGoal)
+		previousAmount = stretchGoalInput.Amount
+	}
+
+	err = s.repository.ReplaceStretchGoals(campaign.ID, stretchGoals)
+
+	if err != nil {
+		return campaign, err
+	}
+
+	updatedCampaign, err := s.repository.FindById(campaign.ID)
+
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	return updatedCampaign, nil
+}
+
+// BackfillStatus keeps campaigns from before moderation live, without a
+// status they would be hidden everywhere and refuse donations.
+func (s *service) BackfillStatus() (int64, error) {
+	return s.repository.ApproveUnmoderated()
+}
+
+// EnsureUniqueSlugs gives campaigns that share a slug with an older one a
+// slug of their own, then adds the unique index saveWithSlug relies on. It
+// returns how many campaigns got a new slug.
+func (s *service) EnsureUniqueSlugs() (int, error) {
+	duplicates, err := s.repository.FindDuplicateSlugs()
+
+	if err != nil {
+		return 0, err
+	}
+
+	for i, duplicate := range duplicates {
+		newSlug, err := s.generateSlug(duplicate.Name, duplicate.UserID, duplicate.ID)
+
+		if err != nil {
+			return i, err
+		}
+
+		err = s.repository.UpdateSlug(duplicate.ID, newSlug)
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(duplicates), s.repository.CreateSlugIndex()
+}
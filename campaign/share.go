@@ -0,0 +1,227 @@
+package campaign
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const SHARE_IMAGE_WIDTH = 1200
+const SHARE_IMAGE_HEIGHT = 630
+
+var shareBackground = color.RGBA{0x1d, 0x4e, 0x89, 0xff}
+var shareProgressTrack = color.RGBA{0x55, 0x55, 0x55, 0x55}
+var shareProgressBar = color.RGBA{0x2e, 0xcc, 0x71, 0xff}
+
+type cachedShareImage struct {
+	key   shareImageKey
+	image []byte
+}
+
+// shareImageKey holds everything drawn on the card, a card is rendered again
+// once any of it changes.
+type shareImageKey struct {
+	name          string
+	photo         string
+	currentAmount int
+	goalAmount    int
+}
+
+// shareImages keeps the last card rendered per campaign id, crawlers fetch
+// the same card far more often than a donation changes it.
+var shareImages = struct {
+	sync.Mutex
+	cards map[int]cachedShareImage
+}{cards: map[int]cachedShareImage{}}
+
+// ShareImage returns the Open Graph card of a campaign, rendering it only
+// when it is not cached for the current amount.
+func ShareImage(campaign Campaign) ([]byte, error) {
+	primaryImage, _ := campaign.PrimaryImage()
+
+	key := shareImageKey{
+		name:          campaign.Name,
+		photo:         primaryImage.FileName,
+		currentAmount: campaign.CurrentAmount,
+		goalAmount:    campaign.GoalAmount,
+	}
+
+	shareImages.Lock()
+	cached, ok := shareImages.cards[campaign.ID]
+	shareImages.Unlock()
+
+	if ok && cached.key == key {
+		return cached.image, nil
+	}
+
+	card, err := GenerateShareImage(campaign)
+
+	if err != nil {
+		return card, err
+	}
+
+	shareImages.Lock()
+	shareImages.cards[campaign.ID] = cachedShareImage{key: key, image: card}
+	shareImages.Unlock()
+
+	return card, nil
+}
+
+// GenerateShareImage renders the Open Graph card of a campaign: the primary
+// photo on the left, the title, progress bar and amount on the right.
+func GenerateShareImage(campaign Campaign) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, SHARE_IMAGE_WIDTH, SHARE_IMAGE_HEIGHT))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{shareBackground}, image.Point{}, draw.Src)
+
+	photoArea := image.Rect(0, 0, SHARE_IMAGE_HEIGHT, SHARE_IMAGE_HEIGHT)
+
+	primaryImage, ok := campaign.PrimaryImage()
+
+	if ok {
+		photo, err := loadImage(primaryImage.FileName)
+
+		if err == nil {
+			draw.CatmullRom.Scale(canvas, photoArea, photo, coverRect(photo.Bounds(), photoArea), draw.Src, nil)
+		}
+	}
+
+	titleFace, err := loadFace(gobold.TTF, 44)
+	if err != nil {
+		return nil, err
+	}
+	defer titleFace.Close()
+
+	textFace, err := loadFace(goregular.TTF, 30)
+	if err != nil {
+		return nil, err
+	}
+	defer textFace.Close()
+
+	left := SHARE_IMAGE_HEIGHT + 40
+	textWidth := SHARE_IMAGE_WIDTH - left - 40
+
+	y := 110
+	for _, line := range wrapText(titleFace, campaign.Name, textWidth, 4) {
+		drawText(canvas, titleFace, line, left, y, color.White)
+		y += 56
+	}
+
+	barTop := 390
+	draw.Draw(canvas, image.Rect(left, barTop, left+textWidth, barTop+20), &image.Uniform{shareProgressTrack}, image.Point{}, draw.Over)
+
+	percentage := campaign.FundedPercentage()
+	if percentage > 100 {
+		percentage = 100
+	}
+
+	barWidth := int(float64(textWidth) * percentage / 100)
+	draw.Draw(canvas, image.Rect(left, barTop, left+barWidth, barTop+20), &image.Uniform{shareProgressBar}, image.Point{}, draw.Src)
+
+	drawText(canvas, titleFace, campaign.CurrentAmountFormatIDR(), left, barTop+75, color.White)
+	drawText(canvas, textFace, "terkumpul dari "+campaign.GoalAmountFormatIDR(), left, barTop+120, color.White)
+	drawText(canvas, textFace, "BEKASIBERBAGI", left, SHARE_IMAGE_HEIGHT-30, color.White)
+
+	var buffer bytes.Buffer
+
+	err = png.Encode(&buffer, canvas)
+	if err != nil {
+		return buffer.Bytes(), err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func loadImage(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func loadFace(ttf []byte, size float64) (font.Face, error) {
+	parsed, err := opentype.Parse(ttf)
+	if err != nil {
+		return nil, err
+	}
+
+	return opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// coverRect returns the centered part of src with the aspect ratio of dst,
+// so the photo fills its area without being stretched.
+func coverRect(src image.Rectangle, dst image.Rectangle) image.Rectangle {
+	srcWidth, srcHeight := src.Dx(), src.Dy()
+	dstWidth, dstHeight := dst.Dx(), dst.Dy()
+
+	if srcWidth*dstHeight > srcHeight*dstWidth {
+		width := srcHeight * dstWidth / dstHeight
+		x := src.Min.X + (srcWidth-width)/2
+		return image.Rect(x, src.Min.Y, x+width, src.Max.Y)
+	}
+
+	height := srcWidth * dstHeight / dstWidth
+	y := src.Min.Y + (srcHeight-height)/2
+	return image.Rect(src.Min.X, y, src.Max.X, y+height)
+}
+
+func drawText(canvas *image.RGBA, face font.Face, text string, x int, y int, textColor color.Color) {
+	drawer := font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+
+	drawer.DrawString(text)
+}
+
+func wrapText(face font.Face, text string, maxWidth int, maxLines int) []string {
+	var lines []string
+	line := ""
+
+	for _, word := range strings.Fields(text) {
+		candidate := word
+
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		if font.MeasureString(face, candidate).Ceil() <= maxWidth || line == "" {
+			line = candidate
+			continue
+		}
+
+		lines = append(lines, line)
+		line = word
+
+		if len(lines) == maxLines {
+			break
+		}
+	}
+
+	if line != "" && len(lines) < maxLines {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
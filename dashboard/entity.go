@@ -0,0 +1,52 @@
+package dashboard
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/transaction"
+	"bekasiberbagi/user"
+
+	"github.com/leekchan/accounting"
+)
+
+type Dashboard struct {
+	RaisedToday       int
+	RaisedThisWeek    int
+	RaisedThisMonth   int
+	StatusSummaries   []transaction.StatusSummary
+	TypeSummaries     []transaction.DonationTypeSummary
+	TopByAmount       []campaign.Campaign
+	TopByBackers      []campaign.Campaign
+	NewUsersPerWeek   []user.WeeklyCount
+	DailyTotals       []ChartPoint
+	PendingCount      int
+	PaidCount         int
+	ConversionPercent float64
+}
+
+type ChartPoint struct {
+	Label      string
+	Value      int
+	Percentage float64
+}
+
+func formatIDR(amount int) string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(amount)
+}
+
+func (d Dashboard) RaisedTodayFormatIDR() string {
+	return formatIDR(d.RaisedToday)
+}
+
+func (d Dashboard) RaisedThisWeekFormatIDR() string {
+	return formatIDR(d.RaisedThisWeek)
+}
+
+func (d Dashboard) RaisedThisMonthFormatIDR() string {
+	return formatIDR(d.RaisedThisMonth)
+}
+
+func (p ChartPoint) ValueFormatIDR() string {
+	return formatIDR(p.Value)
+}
@@ -0,0 +1,140 @@
+package dashboard
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/transaction"
+	"bekasiberbagi/user"
+	"time"
+)
+
+type Service interface {
+	GetDashboard() (Dashboard, error)
+}
+
+type service struct {
+	transactionRepository transaction.Repository
+	campaignRepository    campaign.Repository
+	userRepository        user.Repository
+}
+
+func NewService(transactionRepository transaction.Repository, campaignRepository campaign.Repository, userRepository user.Repository) *service {
+	return &service{transactionRepository, campaignRepository, userRepository}
+}
+
+func (s *service) GetDashboard() (Dashboard, error) {
+	dashboard := Dashboard{}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	startOfWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	var err error
+
+	dashboard.RaisedToday, err = s.transactionRepository.SumPaidSince(today)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.RaisedThisWeek, err = s.transactionRepository.SumPaidSince(startOfWeek)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.RaisedThisMonth, err = s.transactionRepository.SumPaidSince(startOfMonth)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.StatusSummaries, err = s.transactionRepository.SummarizeByStatus()
+	if err != nil {
+		return dashboard, err
+	}
+
+	for _, summary := range dashboard.StatusSummaries {
+		if summary.Status == "paid" {
+			dashboard.PaidCount = summary.Count
+		}
+
+		if summary.Status == "pending" {
+			dashboard.PendingCount = summary.Count
+		}
+	}
+
+	// Pending transactions may still be paid, conversion only counts the
+	// ones Midtrans settled: paid, refunded, expired, denied or cancelled.
+	total := 0
+	for _, summary := range dashboard.StatusSummaries {
+		if summary.Status == "pending" {
+			continue
+		}
+
+		total = total + summary.Count
+	}
+
+	if total > 0 {
+		dashboard.ConversionPercent = float64(dashboard.PaidCount) * 100 / float64(total)
+	}
+
+	dashboard.TypeSummaries, err = s.transactionRepository.SummarizePaidByDonationType()
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.TopByAmount, err = s.campaignRepository.FindTopByAmount(5)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.TopByBackers, err = s.campaignRepository.FindTopByBackers(5)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.NewUsersPerWeek, err = s.userRepository.CountNewPerWeek(startOfWeek.AddDate(0, 0, -7*11))
+	if err != nil {
+		return dashboard, err
+	}
+
+	dailyTotals, err := s.transactionRepository.DailyPaidTotals(today.AddDate(0, 0, -29))
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.DailyTotals = buildDailyChart(today.AddDate(0, 0, -29), 30, dailyTotals)
+
+	return dashboard, nil
+}
+
+func buildDailyChart(start time.Time, days int, dailyTotals []transaction.DailyTotal) []ChartPoint {
+	amountByDate := map[string]int{}
+
+	for _, dailyTotal := range dailyTotals {
+		amountByDate[dailyTotal.Date.Format("2006-01-02")] = dailyTotal.Amount
+	}
+
+	max := 0
+	points := []ChartPoint{}
+
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+
+		point := ChartPoint{}
+		point.Label = date.Format("02 Jan")
+		point.Value = amountByDate[date.Format("2006-01-02")]
+
+		if point.Value > max {
+			max = point.Value
+		}
+
+		points = append(points, point)
+	}
+
+	if max > 0 {
+		for i := range points {
+			points[i].Percentage = float64(points[i].Value) * 100 / float64(max)
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,127 @@
+package datatable
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const MAX_LENGTH = 100
+
+type Request struct {
+	Draw         int
+	Start        int
+	Length       int
+	Search       string
+	OrderColumn  string
+	OrderDir     string
+	ColumnSearch map[string]string
+}
+
+type Response struct {
+	Draw            int         `json:"draw"`
+	RecordsTotal    int64       `json:"recordsTotal"`
+	RecordsFiltered int64       `json:"recordsFiltered"`
+	Data            interface{} `json:"data"`
+}
+
+// ParseRequest reads the query parameters sent by DataTables when
+// serverSide is enabled.
+func ParseRequest(values url.Values) Request {
+	request := Request{}
+	request.Draw, _ = strconv.Atoi(values.Get("draw"))
+	request.Start, _ = strconv.Atoi(values.Get("start"))
+	request.Length, _ = strconv.Atoi(values.Get("length"))
+	request.Search = strings.TrimSpace(values.Get("search[value]"))
+	request.ColumnSearch = map[string]string{}
+
+	if request.Start < 0 {
+		request.Start = 0
+	}
+
+	if request.Length <= 0 || request.Length > MAX_LENGTH {
+		request.Length = MAX_LENGTH
+	}
+
+	for i := 0; ; i++ {
+		data, ok := values[fmt.Sprintf("columns[%d][data]", i)]
+
+		if !ok {
+			break
+		}
+
+		search := strings.TrimSpace(values.Get(fmt.Sprintf("columns[%d][search][value]", i)))
+
+		if search != "" {
+			request.ColumnSearch[data[0]] = search
+		}
+	}
+
+	orderIndex := values.Get("order[0][column]")
+
+	if orderIndex != "" {
+		request.OrderColumn = values.Get(fmt.Sprintf("columns[%s][data]", orderIndex))
+		request.OrderDir = "asc"
+
+		if values.Get("order[0][dir]") == "desc" {
+			request.OrderDir = "desc"
+		}
+	}
+
+	return request
+}
+
+func NewResponse(request Request, total int64, filtered int64, data interface{}) Response {
+	response := Response{}
+	response.Draw = request.Draw
+	response.RecordsTotal = total
+	response.RecordsFiltered = filtered
+	response.Data = data
+
+	return response
+}
+
+// Filter applies the global and per-column search. Only the columns listed
+// in the map (DataTables data name to SQL column) are searchable.
+func (r Request) Filter(query *gorm.DB, columns map[string]string) *gorm.DB {
+	if r.Search != "" {
+		var conditions []string
+		var args []interface{}
+
+		for _, column := range columns {
+			conditions = append(conditions, column+" LIKE ?")
+			args = append(args, "%"+r.Search+"%")
+		}
+
+		query = query.Where(strings.Join(conditions, " OR "), args...)
+	}
+
+	for data, search := range r.ColumnSearch {
+		column, ok := columns[data]
+
+		if !ok {
+			continue
+		}
+
+		query = query.Where(column+" LIKE ?", "%"+search+"%")
+	}
+
+	return query
+}
+
+// Paginate applies ordering, offset and limit. Unknown order columns fall
+// back to the given default order.
+func (r Request) Paginate(query *gorm.DB, columns map[string]string, defaultOrder string) *gorm.DB {
+	column, ok := columns[r.OrderColumn]
+
+	if ok {
+		query = query.Order(column + " " + r.OrderDir)
+	} else {
+		query = query.Order(defaultOrder)
+	}
+
+	return query.Offset(r.Start).Limit(r.Length)
+}
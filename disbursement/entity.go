@@ -0,0 +1,62 @@
+package disbursement
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
+const STATUS_PENDING = "pending"
+const STATUS_APPROVED = "approved"
+const STATUS_REJECTED = "rejected"
+
+// PROOF_STORAGE_PATH keeps transfer proofs private next to the campaign
+// verification documents.
+const PROOF_STORAGE_PATH = "storage/disbursements"
+
+type Disbursement struct {
+	ID              int
+	CampaignID      int
+	UserID          int
+	Amount          int
+	BankName        string
+	AccountNumber   string
+	AccountName     string
+	Note            string
+	Status          string
+	RejectionReason string
+	ProofFileName   string
+	ReviewedByID    int
+	ReviewedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Campaign        campaign.Campaign
+	User            user.User
+}
+
+// Balance extends the ledger balance of a campaign with the amount that is
+// already requested but not decided yet, which cannot be requested again.
+type Balance struct {
+	CampaignID   int
+	Raised       int
+	Fees         int
+	Refunded     int
+	Disbursed    int
+	Available    int
+	Pending      int
+	Withdrawable int
+}
+
+func (b Balance) FormatIDR(amount int) string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(amount)
+}
+
+func (d Disbursement) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(d.Amount)
+}
@@ -0,0 +1,69 @@
+package disbursement
+
+import "time"
+
+type DisbursementFormatter struct {
+	ID              int        `json:"id"`
+	CampaignID      int        `json:"campaign_id"`
+	Amount          int        `json:"amount"`
+	BankName        string     `json:"bank_name"`
+	AccountNumber   string     `json:"account_number"`
+	AccountName     string     `json:"account_name"`
+	Note            string     `json:"note"`
+	Status          string     `json:"status"`
+	RejectionReason string     `json:"rejection_reason"`
+	ReviewedAt      *time.Time `json:"reviewed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func FormatDisbursement(disbursement Disbursement) DisbursementFormatter {
+	formatter := DisbursementFormatter{}
+	formatter.ID = disbursement.ID
+	formatter.CampaignID = disbursement.CampaignID
+	formatter.Amount = disbursement.Amount
+	formatter.BankName = disbursement.BankName
+	formatter.AccountNumber = disbursement.AccountNumber
+	formatter.AccountName = disbursement.AccountName
+	formatter.Note = disbursement.Note
+	formatter.Status = disbursement.Status
+	formatter.RejectionReason = disbursement.RejectionReason
+	formatter.ReviewedAt = disbursement.ReviewedAt
+	formatter.CreatedAt = disbursement.CreatedAt
+
+	return formatter
+}
+
+func FormatDisbursements(disbursements []Disbursement) []DisbursementFormatter {
+	disbursementsFormatter := []DisbursementFormatter{}
+
+	for _, disbursement := range disbursements {
+		disbursementsFormatter = append(disbursementsFormatter, FormatDisbursement(disbursement))
+	}
+
+	return disbursementsFormatter
+}
+
+type BalanceFormatter struct {
+	CampaignID   int `json:"campaign_id"`
+	Raised       int `json:"raised"`
+	Fees         int `json:"fees"`
+	Refunded     int `json:"refunded"`
+	Disbursed    int `json:"disbursed"`
+	Remaining    int `json:"remaining"`
+	Pending      int `json:"pending"`
+	Withdrawable int `json:"withdrawable"`
+}
+
+func FormatBalance(balance Balance) BalanceFormatter {
+	formatter := BalanceFormatter{}
+	formatter.CampaignID = balance.CampaignID
+	formatter.Raised = balance.Raised
+	formatter.Fees = balance.Fees
+	formatter.Refunded = balance.Refunded
+	formatter.Disbursed = balance.Disbursed
+	formatter.Remaining = balance.Available
+	formatter.Pending = balance.Pending
+	formatter.Withdrawable = balance.Withdrawable
+
+	return formatter
+}
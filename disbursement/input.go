@@ -0,0 +1,24 @@
+package disbursement
+
+import "bekasiberbagi/user"
+
+type GetCampaignDisbursementInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
+
+type CreateDisbursementInput struct {
+	CampaignID    int
+	Amount        int    `json:"amount" binding:"required,gt=0"`
+	BankName      string `json:"bank_name" binding:"required"`
+	AccountNumber string `json:"account_number" binding:"required"`
+	AccountName   string `json:"account_name" binding:"required"`
+	Note          string `json:"note"`
+	User          user.User
+}
+
+type FormReviewDisbursementInput struct {
+	ID         int
+	Reason     string `form:"reason"`
+	ReviewerID int
+}
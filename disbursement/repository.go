@@ -0,0 +1,131 @@
+package disbursement
+
+import (
+	"bekasiberbagi/ledger"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Save(disbursement Disbursement) (Disbursement, error)
+	Update(disbursement Disbursement) (Disbursement, error)
+	Approve(disbursement Disbursement, journal ledger.LedgerJournal) (Disbursement, error)
+	FindById(disbursementId int) (Disbursement, error)
+	FindByCampaignID(campaignId int) ([]Disbursement, error)
+	FindAll() ([]Disbursement, error)
+	SumPendingByCampaignID(campaignId int) (int, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(disbursement Disbursement) (Disbursement, error) {
+	err := r.db.Create(&disbursement).Error
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	return disbursement, nil
+}
+
+func (r *repository) Update(disbursement Disbursement) (Disbursement, error) {
+	err := r.db.Omit("Campaign", "User").Save(&disbursement).Error
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	return disbursement, nil
+}
+
+// Approve saves the approval together with its ledger journal, so money is
+// never paid out without being booked. The pending status is checked again
+// inside the transaction in case another admin reviewed the request first.
+func (r *repository) Approve(disbursement Disbursement, journal ledger.LedgerJournal) (Disbursement, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Disbursement{}).Where("id = ? AND status = ?", disbursement.ID, STATUS_PENDING).Updates(map[string]interface{}{
+			"status":          disbursement.Status,
+			"proof_file_name": disbursement.ProofFileName,
+			"reviewed_by_id":  disbursement.ReviewedByID,
+			"reviewed_at":     disbursement.ReviewedAt,
+			"updated_at":      disbursement.UpdatedAt,
+		})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("DISBURSEMENT IS NOT PENDING")
+		}
+
+		return tx.Create(&journal).Error
+	})
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	return disbursement, nil
+}
+
+func (r *repository) FindById(disbursementId int) (Disbursement, error) {
+	var disbursement Disbursement
+
+	err := r.db.Preload("Campaign").Preload("User").Where("id = ?", disbursementId).Find(&disbursement).Error
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	return disbursement, nil
+}
+
+func (r *repository) FindByCampaignID(campaignId int) ([]Disbursement, error) {
+	var disbursements []Disbursement
+
+	err := r.db.Where("campaign_id = ?", campaignId).Order("id desc").Find(&disbursements).Error
+
+	if err != nil {
+		return disbursements, err
+	}
+
+	return disbursements, nil
+}
+
+// FindAll lists pending requests first so the admin queue starts with the
+// ones that still need a decision.
+func (r *repository) FindAll() ([]Disbursement, error) {
+	var disbursements []Disbursement
+
+	err := r.db.Preload("Campaign").Preload("User").
+		Order("CASE WHEN status = 'pending' THEN 0 ELSE 1 END").
+		Order("id desc").
+		Limit(200).
+		Find(&disbursements).Error
+
+	if err != nil {
+		return disbursements, err
+	}
+
+	return disbursements, nil
+}
+
+func (r *repository) SumPendingByCampaignID(campaignId int) (int, error) {
+	var total int
+
+	err := r.db.Model(&Disbursement{}).Select("COALESCE(SUM(amount), 0)").Where("campaign_id = ? AND status = ?", campaignId, STATUS_PENDING).Scan(&total).Error
+
+	if err != nil {
+		return total, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,239 @@
+package disbursement
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/ledger"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Service interface {
+	RequestDisbursement(input CreateDisbursementInput) (Disbursement, error)
+	GetCampaignDisbursements(input GetCampaignDisbursementInput) ([]Disbursement, error)
+	GetDisbursements() ([]Disbursement, error)
+	GetDisbursementById(id int) (Disbursement, error)
+	GetBalance(campaignId int) (Balance, error)
+	ApproveDisbursement(input FormReviewDisbursementInput, proofFileName string) (Disbursement, error)
+	RejectDisbursement(input FormReviewDisbursementInput) (Disbursement, error)
+}
+
+type service struct {
+	repository         Repository
+	campaignRepository campaign.Repository
+	ledgerService      ledger.Service
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, ledgerService ledger.Service) *service {
+	return &service{repository, campaignRepository, ledgerService}
+}
+
+func (s *service) RequestDisbursement(input CreateDisbursementInput) (Disbursement, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return Disbursement{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return Disbursement{}, errors.New("USER UNAUTHORIZED TO WITHDRAW FROM THIS CAMPAIGN")
+	}
+
+	balance, err := s.GetBalance(campaign.ID)
+
+	if err != nil {
+		return Disbursement{}, err
+	}
+
+	if input.Amount > balance.Withdrawable {
+		return Disbursement{}, errors.New("AMOUNT EXCEEDS AVAILABLE BALANCE")
+	}
+
+	disbursement := Disbursement{}
+	disbursement.CampaignID = campaign.ID
+	disbursement.UserID = input.User.ID
+	disbursement.Amount = input.Amount
+	disbursement.BankName = input.BankName
+	disbursement.AccountNumber = input.AccountNumber
+	disbursement.AccountName = input.AccountName
+	disbursement.Note = input.Note
+	disbursement.Status = STATUS_PENDING
+	disbursement.CreatedAt = time.Now()
+	disbursement.UpdatedAt = time.Now()
+
+	newDisbursement, err := s.repository.Save(disbursement)
+
+	if err != nil {
+		return newDisbursement, err
+	}
+
+	return newDisbursement, nil
+}
+
+func (s *service) GetCampaignDisbursements(input GetCampaignDisbursementInput) ([]Disbursement, error) {
+	campaign, err := s.campaignRepository.FindById(input.ID)
+
+	if err != nil {
+		return []Disbursement{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return []Disbursement{}, errors.New("NOT AUTHORIZATION OF THIS ITEM")
+	}
+
+	disbursements, err := s.repository.FindByCampaignID(campaign.ID)
+
+	if err != nil {
+		return disbursements, err
+	}
+
+	return disbursements, nil
+}
+
+func (s *service) GetDisbursements() ([]Disbursement, error) {
+	disbursements, err := s.repository.FindAll()
+
+	if err != nil {
+		return disbursements, err
+	}
+
+	return disbursements, nil
+}
+
+func (s *service) GetDisbursementById(id int) (Disbursement, error) {
+	disbursement, err := s.repository.FindById(id)
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	if disbursement.ID == 0 {
+		return disbursement, errors.New("DISBURSEMENT NOT FOUND")
+	}
+
+	return disbursement, nil
+}
+
+func (s *service) GetBalance(campaignId int) (Balance, error) {
+	ledgerBalance, err := s.ledgerService.GetBalance(campaignId)
+
+	if err != nil {
+		return Balance{}, err
+	}
+
+	pending, err := s.repository.SumPendingByCampaignID(campaignId)
+
+	if err != nil {
+		return Balance{}, err
+	}
+
+	balance := Balance{}
+	balance.CampaignID = campaignId
+	balance.Raised = ledgerBalance.Raised
+	balance.Fees = ledgerBalance.Fees
+	balance.Refunded = ledgerBalance.Refunded
+	balance.Disbursed = ledgerBalance.Disbursed
+	balance.Available = ledgerBalance.Available
+	balance.Pending = pending
+	balance.Withdrawable = ledgerBalance.Available - pending
+
+	if balance.Withdrawable < 0 {
+		balance.Withdrawable = 0
+	}
+
+	return balance, nil
+}
+
+func (s *service) ApproveDisbursement(input FormReviewDisbursementInput, proofFileName string) (Disbursement, error) {
+	if input.ReviewerID == 0 {
+		return Disbursement{}, errors.New("REVIEWER IS REQUIRED")
+	}
+
+	disbursement, err := s.findPending(input.ID)
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	if proofFileName == "" {
+		return disbursement, errors.New("TRANSFER PROOF IS REQUIRED")
+	}
+
+	ledgerBalance, err := s.ledgerService.GetBalance(disbursement.CampaignID)
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	if disbursement.Amount > ledgerBalance.Available {
+		return disbursement, errors.New("AMOUNT EXCEEDS AVAILABLE BALANCE")
+	}
+
+	journal, err := s.ledgerService.Prepare(ledger.DisbursementInput(disbursement.CampaignID, disbursement.ID, disbursement.Amount))
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	now := time.Now()
+
+	disbursement.Status = STATUS_APPROVED
+	disbursement.ProofFileName = proofFileName
+	disbursement.ReviewedByID = input.ReviewerID
+	disbursement.ReviewedAt = &now
+	disbursement.UpdatedAt = now
+
+	updatedDisbursement, err := s.repository.Approve(disbursement, journal)
+
+	if err != nil {
+		return updatedDisbursement, err
+	}
+
+	return updatedDisbursement, nil
+}
+
+func (s *service) RejectDisbursement(input FormReviewDisbursementInput) (Disbursement, error) {
+	if input.ReviewerID == 0 {
+		return Disbursement{}, errors.New("REVIEWER IS REQUIRED")
+	}
+
+	if strings.TrimSpace(input.Reason) == "" {
+		return Disbursement{}, errors.New("REJECTION REASON IS REQUIRED")
+	}
+
+	disbursement, err := s.findPending(input.ID)
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	now := time.Now()
+
+	disbursement.Status = STATUS_REJECTED
+	disbursement.RejectionReason = strings.TrimSpace(input.Reason)
+	disbursement.ReviewedByID = input.ReviewerID
+	disbursement.ReviewedAt = &now
+	disbursement.UpdatedAt = now
+
+	updatedDisbursement, err := s.repository.Update(disbursement)
+
+	if err != nil {
+		return updatedDisbursement, err
+	}
+
+	return updatedDisbursement, nil
+}
+
+func (s *service) findPending(id int) (Disbursement, error) {
+	disbursement, err := s.GetDisbursementById(id)
+
+	if err != nil {
+		return disbursement, err
+	}
+
+	if disbursement.Status != STATUS_PENDING {
+		return disbursement, errors.New("DISBURSEMENT IS NOT PENDING")
+	}
+
+	return disbursement, nil
+}
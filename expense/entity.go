@@ -0,0 +1,61 @@
+package expense
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
+const CATEGORY_MEDICAL = "medical"
+const CATEGORY_EDUCATION = "education"
+const CATEGORY_FOOD = "food"
+const CATEGORY_LOGISTICS = "logistics"
+const CATEGORY_CONSTRUCTION = "construction"
+const CATEGORY_OPERATIONAL = "operational"
+const CATEGORY_OTHER = "other"
+
+// RECEIPT_STORAGE_PATH is served publicly, receipts are part of the
+// transparency report donors can look at.
+const RECEIPT_STORAGE_PATH = "uploads/expense"
+
+var receiptExtensions = map[string]bool{
+	".pdf":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+// IsAllowedReceipt keeps anything a browser would run, like html or svg, out
+// of the publicly served receipts.
+func IsAllowedReceipt(fileName string) bool {
+	return receiptExtensions[strings.ToLower(filepath.Ext(fileName))]
+}
+
+type Expense struct {
+	ID          int
+	CampaignID  int
+	UserID      int
+	Amount      int
+	Category    string
+	SpentAt     time.Time
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Receipts    []ExpenseReceipt
+}
+
+func (e Expense) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(e.Amount)
+}
+
+type ExpenseReceipt struct {
+	ID        int
+	ExpenseID int
+	FileName  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
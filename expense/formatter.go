@@ -0,0 +1,41 @@
+package expense
+
+type ExpenseFormatter struct {
+	ID          int      `json:"id"`
+	CampaignID  int      `json:"campaign_id"`
+	Amount      int      `json:"amount"`
+	Category    string   `json:"category"`
+	SpentAt     string   `json:"spent_at"`
+	Description string   `json:"description"`
+	Receipts    []string `json:"receipts"`
+}
+
+func FormatExpense(expense Expense) ExpenseFormatter {
+	formatter := ExpenseFormatter{}
+	formatter.ID = expense.ID
+	formatter.CampaignID = expense.CampaignID
+	formatter.Amount = expense.Amount
+	formatter.Category = expense.Category
+	formatter.SpentAt = expense.SpentAt.Format("2006-01-02")
+	formatter.Description = expense.Description
+
+	receipts := []string{}
+
+	for _, receipt := range expense.Receipts {
+		receipts = append(receipts, receipt.FileName)
+	}
+
+	formatter.Receipts = receipts
+
+	return formatter
+}
+
+func FormatExpenses(expenses []Expense) []ExpenseFormatter {
+	expensesFormatter := []ExpenseFormatter{}
+
+	for _, expense := range expenses {
+		expensesFormatter = append(expensesFormatter, FormatExpense(expense))
+	}
+
+	return expensesFormatter
+}
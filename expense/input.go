@@ -0,0 +1,19 @@
+package expense
+
+import (
+	"bekasiberbagi/user"
+	"time"
+)
+
+type GetCampaignExpensesInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CreateExpenseInput struct {
+	CampaignID  int
+	Amount      int       `form:"amount" binding:"required,gt=0"`
+	Category    string    `form:"category" binding:"required,oneof=medical education food logistics construction operational other"`
+	SpentAt     time.Time `form:"spent_at" time_format:"2006-01-02" binding:"required"`
+	Description string    `form:"description" binding:"required"`
+	User        user.User
+}
@@ -0,0 +1,73 @@
+package expense
+
+import (
+	"bekasiberbagi/campaign"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Save(expense Expense) (Expense, error)
+	FindByCampaignID(campaignId int) ([]Expense, error)
+	SumByCampaignID(campaignId int) (int, error)
+	SumByCategory(campaignId int) ([]campaign.SpendingTotal, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(expense Expense) (Expense, error) {
+	err := r.db.Create(&expense).Error
+
+	if err != nil {
+		return expense, err
+	}
+
+	return expense, nil
+}
+
+func (r *repository) FindByCampaignID(campaignId int) ([]Expense, error) {
+	var expenses []Expense
+
+	err := r.db.Where("campaign_id = ?", campaignId).Preload("Receipts").Order("spent_at desc, id desc").Find(&expenses).Error
+
+	if err != nil {
+		return expenses, err
+	}
+
+	return expenses, nil
+}
+
+func (r *repository) SumByCampaignID(campaignId int) (int, error) {
+	var total int
+
+	err := r.db.Model(&Expense{}).Select("COALESCE(SUM(amount), 0)").Where("campaign_id = ?", campaignId).Scan(&total).Error
+
+	if err != nil {
+		return total, err
+	}
+
+	return total, nil
+}
+
+func (r *repository) SumByCategory(campaignId int) ([]campaign.SpendingTotal, error) {
+	var totals []campaign.SpendingTotal
+
+	err := r.db.Model(&Expense{}).
+		Select("category, SUM(amount) AS amount").
+		Where("campaign_id = ?", campaignId).
+		Group("category").
+		Order("amount desc").
+		Scan(&totals).Error
+
+	if err != nil {
+		return totals, err
+	}
+
+	return totals, nil
+}
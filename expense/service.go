@@ -0,0 +1,106 @@
+package expense
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/disbursement"
+	"errors"
+	"time"
+)
+
+type Service interface {
+	CreateExpense(input CreateExpenseInput, receiptFileNames []string) (Expense, error)
+	GetExpenses(input GetCampaignExpensesInput) ([]Expense, error)
+	GetSpendingBreakdown(campaignId int) ([]campaign.SpendingTotal, error)
+}
+
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	disbursementService disbursement.Service
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, disbursementService disbursement.Service) *service {
+	return &service{repository, campaignRepository, disbursementService}
+}
+
+// CreateExpense records spending reported by the organizer. Organizers can
+// only report what has been paid out to them, so the sum of all expenses may
+// not go above the disbursed funds of the campaign.
+func (s *service) CreateExpense(input CreateExpenseInput, receiptFileNames []string) (Expense, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return Expense{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return Expense{}, errors.New("USER UNAUTHORIZED TO REPORT EXPENSES OF THIS CAMPAIGN")
+	}
+
+	if input.SpentAt.After(time.Now()) {
+		return Expense{}, errors.New("EXPENSE DATE CANNOT BE IN THE FUTURE")
+	}
+
+	balance, err := s.disbursementService.GetBalance(campaign.ID)
+
+	if err != nil {
+		return Expense{}, err
+	}
+
+	spent, err := s.repository.SumByCampaignID(campaign.ID)
+
+	if err != nil {
+		return Expense{}, err
+	}
+
+	if spent+input.Amount > balance.Disbursed {
+		return Expense{}, errors.New("EXPENSES EXCEED DISBURSED FUNDS")
+	}
+
+	expense := Expense{}
+	expense.CampaignID = campaign.ID
+	expense.UserID = input.User.ID
+	expense.Amount = input.Amount
+	expense.Category = input.Category
+	expense.SpentAt = input.SpentAt
+	expense.Description = input.Description
+	expense.CreatedAt = time.Now()
+	expense.UpdatedAt = time.Now()
+
+	for _, fileName := range receiptFileNames {
+		receipt := ExpenseReceipt{}
+		receipt.FileName = fileName
+		receipt.CreatedAt = time.Now()
+		receipt.UpdatedAt = time.Now()
+
+		expense.Receipts = append(expense.Receipts, receipt)
+	}
+
+	newExpense, err := s.repository.Save(expense)
+
+	if err != nil {
+		return newExpense, err
+	}
+
+	return newExpense, nil
+}
+
+func (s *service) GetExpenses(input GetCampaignExpensesInput) ([]Expense, error) {
+	expenses, err := s.repository.FindByCampaignID(input.ID)
+
+	if err != nil {
+		return expenses, err
+	}
+
+	return expenses, nil
+}
+
+func (s *service) GetSpendingBreakdown(campaignId int) ([]campaign.SpendingTotal, error) {
+	totals, err := s.repository.SumByCategory(campaignId)
+
+	if err != nil {
+		return totals, err
+	}
+
+	return totals, nil
+}
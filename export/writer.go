@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const FORMAT_CSV = "csv"
+const FORMAT_XLSX = "xlsx"
+
+type Writer interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	if format == FORMAT_CSV {
+		return NewCsvWriter(w), nil
+	}
+
+	if format == FORMAT_XLSX {
+		return NewXlsxWriter(w)
+	}
+
+	return nil, errors.New("UNSUPPORTED EXPORT FORMAT")
+}
+
+func ContentType(format string) string {
+	if format == FORMAT_XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv"
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func NewCsvWriter(w io.Writer) *csvWriter {
+	return &csvWriter{csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Write(row []interface{}) error {
+	record := make([]string, len(row))
+
+	for i, value := range row {
+		record[i] = fmt.Sprint(value)
+
+		if _, ok := value.(string); ok {
+			record[i] = escapeFormula(record[i])
+		}
+	}
+
+	err := w.writer.Write(record)
+
+	if err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// escapeFormula keeps a spreadsheet from running text such as a campaign or
+// donor name as a formula when the CSV is opened. The xlsx writer stores text
+// as inline strings, which are never evaluated.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+// xlsxWriter writes a single sheet workbook row by row, so the sheet is
+// never held in memory as a whole.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+}
+
+func NewXlsxWriter(w io.Writer) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.WriteString(file, part.content)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(file)
+
+	_, err = sheet.WriteString(xlsxSheetHeader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (w *xlsxWriter) Write(row []interface{}) error {
+	w.row++
+
+	_, err := fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+
+	if err != nil {
+		return err
+	}
+
+	for _, value := range row {
+		switch v := value.(type) {
+		case int, int64, float64:
+			_, err = fmt.Fprintf(w.sheet, `<c><v>%v</v></c>`, v)
+		default:
+			_, err = w.sheet.WriteString(`<c t="inlineStr"><is><t>`)
+
+			if err == nil {
+				err = xml.EscapeText(w.sheet, []byte(fmt.Sprint(v)))
+			}
+
+			if err == nil {
+				_, err = w.sheet.WriteString(`</t></is></c>`)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.sheet.WriteString(`</row>`)
+
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	_, err := w.sheet.WriteString(xlsxSheetFooter)
+
+	if err != nil {
+		return err
+	}
+
+	err = w.sheet.Flush()
+
+	if err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
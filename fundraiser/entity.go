@@ -0,0 +1,60 @@
+package fundraiser
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
+const STATUS_ACTIVE = "active"
+const STATUS_HIDDEN = "hidden"
+
+// Fundraiser is a supporter's own page for a campaign. Donations made
+// through it go to the parent campaign and are also counted here.
+type Fundraiser struct {
+	ID            int
+	CampaignID    int
+	UserID        int
+	Title         string
+	Slug          string
+	Story         string
+	GoalAmount    int
+	CurrentAmount int
+	DonorCount    int
+	Status        string
+	HiddenReason  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Campaign      campaign.Campaign
+	User          user.User
+}
+
+func (f Fundraiser) IsActive() bool {
+	return f.Status == STATUS_ACTIVE
+}
+
+func (f Fundraiser) PublicPath() string {
+	return "/f/" + f.Slug
+}
+
+func (f Fundraiser) FundedPercentage() float64 {
+	if f.GoalAmount == 0 {
+		return 0
+	}
+
+	return float64(f.CurrentAmount) * 100 / float64(f.GoalAmount)
+}
+
+func (f Fundraiser) GoalAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(f.GoalAmount)
+}
+
+func (f Fundraiser) CurrentAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(f.CurrentAmount)
+}
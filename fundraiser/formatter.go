@@ -0,0 +1,66 @@
+package fundraiser
+
+import "time"
+
+type FundraiserFormatter struct {
+	ID            int                         `json:"id"`
+	CampaignID    int                         `json:"campaign_id"`
+	Title         string                      `json:"title"`
+	Slug          string                      `json:"slug"`
+	Story         string                      `json:"story"`
+	GoalAmount    int                         `json:"goal_amount"`
+	CurrentAmount int                         `json:"current_amount"`
+	DonorCount    int                         `json:"donor_count"`
+	Status        string                      `json:"status"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	User          FundraiserUserFormatter     `json:"user"`
+	Campaign      FundraiserCampaignFormatter `json:"campaign"`
+}
+
+type FundraiserUserFormatter struct {
+	Name     string `json:"name"`
+	ImageURL string `json:"image_url"`
+}
+
+type FundraiserCampaignFormatter struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func FormatFundraiser(fundraiser Fundraiser) FundraiserFormatter {
+	formatter := FundraiserFormatter{}
+	formatter.ID = fundraiser.ID
+	formatter.CampaignID = fundraiser.CampaignID
+	formatter.Title = fundraiser.Title
+	formatter.Slug = fundraiser.Slug
+	formatter.Story = fundraiser.Story
+	formatter.GoalAmount = fundraiser.GoalAmount
+	formatter.CurrentAmount = fundraiser.CurrentAmount
+	formatter.DonorCount = fundraiser.DonorCount
+	formatter.Status = fundraiser.Status
+	formatter.CreatedAt = fundraiser.CreatedAt
+
+	userFormatter := FundraiserUserFormatter{}
+	userFormatter.Name = fundraiser.User.Name
+	userFormatter.ImageURL = fundraiser.User.AvatarFileName
+
+	formatter.User = userFormatter
+
+	campaignFormatter := FundraiserCampaignFormatter{}
+	campaignFormatter.Name = fundraiser.Campaign.Name
+	campaignFormatter.Slug = fundraiser.Campaign.Slug
+
+	formatter.Campaign = campaignFormatter
+
+	return formatter
+}
+
+func FormatFundraisers(fundraisers []Fundraiser) []FundraiserFormatter {
+	fundraisersFormatter := []FundraiserFormatter{}
+
+	for _, fundraiser := range fundraisers {
+		fundraisersFormatter = append(fundraisersFormatter, FormatFundraiser(fundraiser))
+	}
+
+	return fundraisersFormatter
+}
@@ -0,0 +1,25 @@
+package fundraiser
+
+import "bekasiberbagi/user"
+
+type CreateFundraiserInput struct {
+	CampaignID int
+	Title      string `json:"title" binding:"required"`
+	Story      string `json:"story"`
+	GoalAmount int    `json:"goal_amount" binding:"required,gt=0"`
+	User       user.User
+}
+
+type GetCampaignFundraisersInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type GetFundraiserBySlugInput struct {
+	Slug string `uri:"slug" binding:"required"`
+}
+
+type FormStatusFundraiserInput struct {
+	ID     int
+	Status string `form:"status" binding:"required,oneof=active hidden"`
+	Reason string `form:"reason"`
+}
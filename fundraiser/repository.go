@@ -0,0 +1,107 @@
+package fundraiser
+
+import "gorm.io/gorm"
+
+type Repository interface {
+	Save(fundraiser Fundraiser) (Fundraiser, error)
+	Update(fundraiser Fundraiser) (Fundraiser, error)
+	FindById(fundraiserId int) (Fundraiser, error)
+	FindBySlug(slug string) (Fundraiser, error)
+	FindTopByCampaignID(campaignId int, limit int) ([]Fundraiser, error)
+	FindAll() ([]Fundraiser, error)
+	SlugExists(slug string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(fundraiser Fundraiser) (Fundraiser, error) {
+	err := r.db.Omit("Campaign", "User").Create(&fundraiser).Error
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	return fundraiser, nil
+}
+
+func (r *repository) Update(fundraiser Fundraiser) (Fundraiser, error) {
+	err := r.db.Omit("Campaign", "User").Save(&fundraiser).Error
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	return fundraiser, nil
+}
+
+func (r *repository) FindById(fundraiserId int) (Fundraiser, error) {
+	var fundraiser Fundraiser
+
+	err := r.db.Preload("Campaign").Preload("User").Where("id = ?", fundraiserId).Find(&fundraiser).Error
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	return fundraiser, nil
+}
+
+func (r *repository) FindBySlug(slug string) (Fundraiser, error) {
+	var fundraiser Fundraiser
+
+	err := r.db.Preload("Campaign").Preload("User").Where("slug = ?", slug).Find(&fundraiser).Error
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	return fundraiser, nil
+}
+
+// FindTopByCampaignID is the leaderboard of a campaign, hidden fundraisers
+// are left out.
+func (r *repository) FindTopByCampaignID(campaignId int, limit int) ([]Fundraiser, error) {
+	var fundraisers []Fundraiser
+
+	err := r.db.Preload("User").
+		Where("campaign_id = ? AND status = ?", campaignId, STATUS_ACTIVE).
+		Order("current_amount desc, id asc").
+		Limit(limit).
+		Find(&fundraisers).Error
+
+	if err != nil {
+		return fundraisers, err
+	}
+
+	return fundraisers, nil
+}
+
+func (r *repository) FindAll() ([]Fundraiser, error) {
+	var fundraisers []Fundraiser
+
+	err := r.db.Preload("Campaign").Preload("User").Order("id desc").Limit(200).Find(&fundraisers).Error
+
+	if err != nil {
+		return fundraisers, err
+	}
+
+	return fundraisers, nil
+}
+
+func (r *repository) SlugExists(slug string) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&Fundraiser{}).Where("slug = ?", slug).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
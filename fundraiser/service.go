@@ -0,0 +1,163 @@
+package fundraiser
+
+import (
+	"bekasiberbagi/campaign"
+	"errors"
+	"fmt"
+
+	"github.com/gosimple/slug"
+)
+
+const LEADERBOARD_LIMIT = 20
+
+type Service interface {
+	CreateFundraiser(input CreateFundraiserInput) (Fundraiser, error)
+	GetLeaderboard(input GetCampaignFundraisersInput) ([]Fundraiser, error)
+	GetFundraiserBySlug(input GetFundraiserBySlugInput) (Fundraiser, error)
+	GetFundraiserById(fundraiserId int) (Fundraiser, error)
+	GetFundraisers() ([]Fundraiser, error)
+	UpdateStatus(form FormStatusFundraiserInput) (Fundraiser, error)
+}
+
+type service struct {
+	repository         Repository
+	campaignRepository campaign.Repository
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository) *service {
+	return &service{repository, campaignRepository}
+}
+
+func (s *service) CreateFundraiser(input CreateFundraiserInput) (Fundraiser, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return Fundraiser{}, err
+	}
+
+	if campaign.ID == 0 || !campaign.IsLive() {
+		return Fundraiser{}, errors.New("CAMPAIGN IS NOT ACCEPTING DONATIONS")
+	}
+
+	fundraiserSlug, err := s.generateSlug(input.Title, input.User.ID)
+
+	if err != nil {
+		return Fundraiser{}, err
+	}
+
+	fundraiser := Fundraiser{}
+	fundraiser.CampaignID = campaign.ID
+	fundraiser.UserID = input.User.ID
+	fundraiser.Title = input.Title
+	fundraiser.Slug = fundraiserSlug
+	fundraiser.Story = input.Story
+	fundraiser.GoalAmount = input.GoalAmount
+	fundraiser.Status = STATUS_ACTIVE
+
+	newFundraiser, err := s.repository.Save(fundraiser)
+
+	if err != nil {
+		return newFundraiser, err
+	}
+
+	newFundraiser.Campaign = campaign
+	newFundraiser.User = input.User
+
+	return newFundraiser, nil
+}
+
+func (s *service) generateSlug(title string, userId int) (string, error) {
+	baseSlug := slug.Make(fmt.Sprintf("%s %d", title, userId))
+	candidate := baseSlug
+
+	for i := 2; ; i++ {
+		exists, err := s.repository.SlugExists(candidate)
+
+		if err != nil {
+			return candidate, err
+		}
+
+		if !exists {
+			return candidate, nil
+		}
+
+		candidate = fmt.Sprintf("%s-%d", baseSlug, i)
+	}
+}
+
+func (s *service) GetLeaderboard(input GetCampaignFundraisersInput) ([]Fundraiser, error) {
+	fundraisers, err := s.repository.FindTopByCampaignID(input.ID, LEADERBOARD_LIMIT)
+
+	if err != nil {
+		return fundraisers, err
+	}
+
+	return fundraisers, nil
+}
+
+// GetFundraiserBySlug only finds pages the public may see, a hidden
+// fundraiser or one of a campaign that is not live is not found.
+func (s *service) GetFundraiserBySlug(input GetFundraiserBySlugInput) (Fundraiser, error) {
+	fundraiser, err := s.repository.FindBySlug(input.Slug)
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	if fundraiser.ID == 0 || !fundraiser.IsActive() || !fundraiser.Campaign.IsLive() {
+		return fundraiser, errors.New("FUNDRAISER NOT FOUND")
+	}
+
+	return fundraiser, nil
+}
+
+func (s *service) GetFundraiserById(fundraiserId int) (Fundraiser, error) {
+	fundraiser, err := s.repository.FindById(fundraiserId)
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	if fundraiser.ID == 0 {
+		return fundraiser, errors.New("FUNDRAISER NOT FOUND")
+	}
+
+	return fundraiser, nil
+}
+
+func (s *service) GetFundraisers() ([]Fundraiser, error) {
+	fundraisers, err := s.repository.FindAll()
+
+	if err != nil {
+		return fundraisers, err
+	}
+
+	return fundraisers, nil
+}
+
+func (s *service) UpdateStatus(form FormStatusFundraiserInput) (Fundraiser, error) {
+	fundraiser, err := s.GetFundraiserById(form.ID)
+
+	if err != nil {
+		return fundraiser, err
+	}
+
+	if form.Status == STATUS_HIDDEN && form.Reason == "" {
+		return fundraiser, errors.New("REASON IS REQUIRED TO HIDE A FUNDRAISER")
+	}
+
+	fundraiser.Status = form.Status
+	fundraiser.HiddenReason = ""
+
+	if form.Status == STATUS_HIDDEN {
+		fundraiser.HiddenReason = form.Reason
+	}
+
+	updatedFundraiser, err := s.repository.Update(fundraiser)
+
+	if err != nil {
+		return updatedFundraiser, err
+	}
+
+	return updatedFundraiser, nil
+}
@@ -3,27 +3,33 @@ module bekasiberbagi
 go 1.16
 
 require (
-	github.com/gin-contrib/cors v1.3.1 // indirect
-	github.com/gin-contrib/multitemplate v0.0.0-20210428235909-8a2f6dd269a0 // indirect
-	github.com/gin-contrib/sessions v0.0.3 // indirect
+	github.com/gin-contrib/cors v1.3.1
+	github.com/gin-contrib/multitemplate v0.0.0-20210428235909-8a2f6dd269a0
+	github.com/gin-contrib/sessions v0.0.3
 	github.com/gin-contrib/static v0.0.1
 	github.com/gin-gonic/gin v1.7.3
 	github.com/go-playground/validator/v10 v10.8.0
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/gosimple/slug v1.10.0
 	github.com/joho/godotenv v1.3.0
 	github.com/json-iterator/go v1.1.11 // indirect
-	github.com/leekchan/accounting v1.0.0 // indirect
+	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/leekchan/accounting v1.0.0
 	github.com/mattn/go-isatty v0.0.13 // indirect
+	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/midtrans/midtrans-go v1.2.1 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.1 // indirect
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/ugorji/go v1.2.6 // indirect
-	github.com/veritrans/go-midtrans v0.0.0-20210616100512-16326c5eeb00 // indirect
+	github.com/veritrans/go-midtrans v0.0.0-20210616100512-16326c5eeb00
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
+	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d
 	google.golang.org/protobuf v1.27.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gorm.io/driver/mysql v1.1.1
+	gorm.io/driver/sqlite v1.1.4
 	gorm.io/gorm v1.21.12
 )
@@ -0,0 +1,61 @@
+package goods
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"time"
+)
+
+const STATUS_PLEDGED = "pledged"
+const STATUS_RECEIVED = "received"
+const STATUS_CANCELLED = "cancelled"
+
+const DELIVERY_PICKUP = "pickup"
+const DELIVERY_DROPOFF = "dropoff"
+
+// GoodsItem is something a campaign collects in kind, like rice or school
+// supplies. PledgedQuantity is promised by donors and not received yet.
+type GoodsItem struct {
+	ID               int
+	CampaignID       int
+	Name             string
+	Unit             string
+	Description      string
+	TargetQuantity   int
+	PledgedQuantity  int
+	ReceivedQuantity int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (i GoodsItem) ReceivedPercentage() float64 {
+	if i.TargetQuantity == 0 {
+		return 0
+	}
+
+	return float64(i.ReceivedQuantity) * 100 / float64(i.TargetQuantity)
+}
+
+// GoodsPledge is a donor's promise to hand over goods, either picked up by a
+// volunteer at PickupAddress or dropped off at the campaign address.
+type GoodsPledge struct {
+	ID               int
+	ItemID           int
+	CampaignID       int
+	UserID           int
+	Quantity         int
+	DeliveryMethod   string
+	PickupAddress    string
+	PickupDate       *time.Time
+	ContactPhone     string
+	Note             string
+	Status           string
+	ReceivedQuantity int
+	ReceivedByID     int
+	ReceivedAt       *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Item             GoodsItem `gorm:"foreignKey:ItemID"`
+	Campaign         campaign.Campaign
+	User             user.User
+}
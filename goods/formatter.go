@@ -0,0 +1,113 @@
+package goods
+
+import "time"
+
+type ItemFormatter struct {
+	ID                 int     `json:"id"`
+	CampaignID         int     `json:"campaign_id"`
+	Name               string  `json:"name"`
+	Unit               string  `json:"unit"`
+	Description        string  `json:"description"`
+	TargetQuantity     int     `json:"target_quantity"`
+	PledgedQuantity    int     `json:"pledged_quantity"`
+	ReceivedQuantity   int     `json:"received_quantity"`
+	ReceivedPercentage float64 `json:"received_percentage"`
+}
+
+func FormatItem(item GoodsItem) ItemFormatter {
+	formatter := ItemFormatter{}
+	formatter.ID = item.ID
+	formatter.CampaignID = item.CampaignID
+	formatter.Name = item.Name
+	formatter.Unit = item.Unit
+	formatter.Description = item.Description
+	formatter.TargetQuantity = item.TargetQuantity
+	formatter.PledgedQuantity = item.PledgedQuantity
+	formatter.ReceivedQuantity = item.ReceivedQuantity
+	formatter.ReceivedPercentage = item.ReceivedPercentage()
+
+	return formatter
+}
+
+func FormatItems(items []GoodsItem) []ItemFormatter {
+	itemsFormatter := []ItemFormatter{}
+
+	for _, item := range items {
+		itemsFormatter = append(itemsFormatter, FormatItem(item))
+	}
+
+	return itemsFormatter
+}
+
+type PledgeFormatter struct {
+	ID               int                     `json:"id"`
+	ItemID           int                     `json:"item_id"`
+	CampaignID       int                     `json:"campaign_id"`
+	DonorName        string                  `json:"donor_name"`
+	Quantity         int                     `json:"quantity"`
+	DeliveryMethod   string                  `json:"delivery_method"`
+	PickupAddress    string                  `json:"pickup_address"`
+	PickupDate       *time.Time              `json:"pickup_date"`
+	ContactPhone     string                  `json:"contact_phone"`
+	Note             string                  `json:"note"`
+	Status           string                  `json:"status"`
+	ReceivedQuantity int                     `json:"received_quantity"`
+	ReceivedAt       *time.Time              `json:"received_at"`
+	CreatedAt        time.Time               `json:"created_at"`
+	Item             PledgeItemFormatter     `json:"item"`
+	Campaign         PledgeCampaignFormatter `json:"campaign"`
+}
+
+type PledgeItemFormatter struct {
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+}
+
+type PledgeCampaignFormatter struct {
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Address string `json:"address"`
+}
+
+func FormatPledge(pledge GoodsPledge) PledgeFormatter {
+	formatter := PledgeFormatter{}
+	formatter.ID = pledge.ID
+	formatter.ItemID = pledge.ItemID
+	formatter.CampaignID = pledge.CampaignID
+	formatter.DonorName = pledge.User.Name
+	formatter.Quantity = pledge.Quantity
+	formatter.DeliveryMethod = pledge.DeliveryMethod
+	formatter.PickupAddress = pledge.PickupAddress
+	formatter.PickupDate = pledge.PickupDate
+	formatter.ContactPhone = pledge.ContactPhone
+	formatter.Note = pledge.Note
+	formatter.Status = pledge.Status
+	formatter.ReceivedQuantity = pledge.ReceivedQuantity
+	formatter.ReceivedAt = pledge.ReceivedAt
+	formatter.CreatedAt = pledge.CreatedAt
+
+	itemFormatter := PledgeItemFormatter{}
+	itemFormatter.Name = pledge.Item.Name
+	itemFormatter.Unit = pledge.Item.Unit
+
+	formatter.Item = itemFormatter
+
+	campaignFormatter := PledgeCampaignFormatter{}
+	campaignFormatter.Name = pledge.Campaign.Name
+	campaignFormatter.Slug = pledge.Campaign.Slug
+	campaignFormatter.Address = pledge.Campaign.Address
+
+	formatter.Campaign = campaignFormatter
+
+	return formatter
+}
+
+func FormatPledges(pledges []GoodsPledge) []PledgeFormatter {
+	pledgesFormatter := []PledgeFormatter{}
+
+	for _, pledge := range pledges {
+		pledgesFormatter = append(pledgesFormatter, FormatPledge(pledge))
+	}
+
+	return pledgesFormatter
+}
@@ -0,0 +1,57 @@
+package goods
+
+import (
+	"bekasiberbagi/user"
+	"time"
+)
+
+type GetCampaignItemsInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CreateItemInput struct {
+	CampaignID     int
+	Name           string `json:"name" binding:"required"`
+	Unit           string `json:"unit" binding:"required"`
+	Description    string `json:"description"`
+	TargetQuantity int    `json:"target_quantity" binding:"required,gt=0"`
+	User           user.User
+}
+
+type GetItemDetailInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// PickupAddress and PickupDate are only needed when the goods are picked up.
+type CreatePledgeInput struct {
+	ItemID         int
+	Quantity       int        `json:"quantity" binding:"required,gt=0"`
+	DeliveryMethod string     `json:"delivery_method" binding:"required,oneof=pickup dropoff"`
+	PickupAddress  string     `json:"pickup_address" binding:"required_if=DeliveryMethod pickup"`
+	PickupDate     *time.Time `json:"pickup_date" binding:"required_if=DeliveryMethod pickup"`
+	ContactPhone   string     `json:"contact_phone" binding:"required"`
+	Note           string     `json:"note"`
+	User           user.User
+}
+
+type GetCampaignPledgesInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
+
+type GetPledgeDetailInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
+
+type FormReceivePledgeInput struct {
+	ID               int
+	ReceivedQuantity int `form:"received_quantity" binding:"required,gt=0"`
+	ReceiverID       int
+}
+
+type ConfirmPledgeReceiptInput struct {
+	ID               int
+	ReceivedQuantity int `json:"received_quantity" binding:"required,gt=0"`
+	User             user.User
+}
@@ -0,0 +1,153 @@
+package goods
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	SaveItem(item GoodsItem) (GoodsItem, error)
+	FindItemById(itemId int) (GoodsItem, error)
+	FindItemsByCampaignID(campaignId int) ([]GoodsItem, error)
+	AddItemQuantities(itemId int, pledged int, received int) error
+	SavePledge(pledge GoodsPledge) (GoodsPledge, error)
+	UpdatePledge(pledge GoodsPledge) (GoodsPledge, error)
+	FindPledgeById(pledgeId int) (GoodsPledge, error)
+	FindPledgesByUserID(userId int) ([]GoodsPledge, error)
+	FindPledgesByCampaignID(campaignId int) ([]GoodsPledge, error)
+	FindAllPledges() ([]GoodsPledge, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) SaveItem(item GoodsItem) (GoodsItem, error) {
+	err := r.db.Create(&item).Error
+
+	if err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *repository) FindItemById(itemId int) (GoodsItem, error) {
+	var item GoodsItem
+
+	err := r.db.Where("id = ?", itemId).Find(&item).Error
+
+	if err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *repository) FindItemsByCampaignID(campaignId int) ([]GoodsItem, error) {
+	var items []GoodsItem
+
+	err := r.db.Where("campaign_id = ?", campaignId).Order("id asc").Find(&items).Error
+
+	if err != nil {
+		return items, err
+	}
+
+	return items, nil
+}
+
+// AddItemQuantities moves the totals in place so pledges handled at the same
+// time do not overwrite each other.
+func (r *repository) AddItemQuantities(itemId int, pledged int, received int) error {
+	err := r.db.Model(&GoodsItem{}).Where("id = ?", itemId).Updates(map[string]interface{}{
+		"pledged_quantity":  gorm.Expr("pledged_quantity + ?", pledged),
+		"received_quantity": gorm.Expr("received_quantity + ?", received),
+		"updated_at":        time.Now(),
+	}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) SavePledge(pledge GoodsPledge) (GoodsPledge, error) {
+	err := r.db.Omit("Item", "Campaign", "User").Create(&pledge).Error
+
+	if err != nil {
+		return pledge, err
+	}
+
+	return pledge, nil
+}
+
+func (r *repository) UpdatePledge(pledge GoodsPledge) (GoodsPledge, error) {
+	err := r.db.Omit("Item", "Campaign", "User").Save(&pledge).Error
+
+	if err != nil {
+		return pledge, err
+	}
+
+	return pledge, nil
+}
+
+func (r *repository) FindPledgeById(pledgeId int) (GoodsPledge, error) {
+	var pledge GoodsPledge
+
+	err := r.db.Preload("Item").Preload("Campaign").Preload("User").Where("id = ?", pledgeId).Find(&pledge).Error
+
+	if err != nil {
+		return pledge, err
+	}
+
+	return pledge, nil
+}
+
+func (r *repository) FindPledgesByUserID(userId int) ([]GoodsPledge, error) {
+	var pledges []GoodsPledge
+
+	err := r.db.Preload("Item").Preload("Campaign").Where("user_id = ?", userId).Order("id desc").Find(&pledges).Error
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+func (r *repository) FindPledgesByCampaignID(campaignId int) ([]GoodsPledge, error) {
+	var pledges []GoodsPledge
+
+	err := r.db.Preload("Item").Preload("User").Where("campaign_id = ?", campaignId).Order("id desc").Find(&pledges).Error
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+// FindAllPledges lists the pledges still waiting for their goods first,
+// soonest pickup first, so volunteers see what to collect next.
+func (r *repository) FindAllPledges() ([]GoodsPledge, error) {
+	var pledges []GoodsPledge
+
+	err := r.db.Preload("Item").Preload("Campaign").Preload("User").
+		Order("CASE WHEN status = 'pledged' THEN 0 ELSE 1 END").
+		Order("pickup_date IS NULL, pickup_date asc").
+		Order("id desc").
+		Limit(200).
+		Find(&pledges).Error
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
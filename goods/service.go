@@ -0,0 +1,301 @@
+package goods
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/notification"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Service interface {
+	CreateItem(input CreateItemInput) (GoodsItem, error)
+	GetItems(input GetCampaignItemsInput) ([]GoodsItem, error)
+	GetGoodsProgress(campaignId int) ([]campaign.GoodsTotal, error)
+	CreatePledge(input CreatePledgeInput) (GoodsPledge, error)
+	GetUserPledges(userId int) ([]GoodsPledge, error)
+	GetCampaignPledges(input GetCampaignPledgesInput) ([]GoodsPledge, error)
+	CancelPledge(input GetPledgeDetailInput) (GoodsPledge, error)
+	GetPledges() ([]GoodsPledge, error)
+	GetPledgeById(pledgeId int) (GoodsPledge, error)
+	ReceivePledge(form FormReceivePledgeInput) (GoodsPledge, error)
+	ConfirmPledgeReceipt(input ConfirmPledgeReceiptInput) (GoodsPledge, error)
+}
+
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	notificationService notification.Service
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, notificationService notification.Service) *service {
+	return &service{repository, campaignRepository, notificationService}
+}
+
+func (s *service) CreateItem(input CreateItemInput) (GoodsItem, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return GoodsItem{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return GoodsItem{}, errors.New("USER UNAUTHORIZED TO EDIT THIS CAMPAIGN")
+	}
+
+	item := GoodsItem{}
+	item.CampaignID = campaign.ID
+	item.Name = input.Name
+	item.Unit = input.Unit
+	item.Description = input.Description
+	item.TargetQuantity = input.TargetQuantity
+
+	newItem, err := s.repository.SaveItem(item)
+
+	if err != nil {
+		return newItem, err
+	}
+
+	return newItem, nil
+}
+
+func (s *service) GetItems(input GetCampaignItemsInput) ([]GoodsItem, error) {
+	items, err := s.repository.FindItemsByCampaignID(input.ID)
+
+	if err != nil {
+		return items, err
+	}
+
+	return items, nil
+}
+
+func (s *service) GetGoodsProgress(campaignId int) ([]campaign.GoodsTotal, error) {
+	items, err := s.repository.FindItemsByCampaignID(campaignId)
+
+	if err != nil {
+		return []campaign.GoodsTotal{}, err
+	}
+
+	totals := []campaign.GoodsTotal{}
+
+	for _, item := range items {
+		totals = append(totals, campaign.GoodsTotal{
+			ItemID:           item.ID,
+			Name:             item.Name,
+			Unit:             item.Unit,
+			TargetQuantity:   item.TargetQuantity,
+			PledgedQuantity:  item.PledgedQuantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+		})
+	}
+
+	return totals, nil
+}
+
+func (s *service) CreatePledge(input CreatePledgeInput) (GoodsPledge, error) {
+	item, err := s.repository.FindItemById(input.ItemID)
+
+	if err != nil {
+		return GoodsPledge{}, err
+	}
+
+	if item.ID == 0 {
+		return GoodsPledge{}, errors.New("ITEM NOT FOUND")
+	}
+
+	campaign, err := s.campaignRepository.FindById(item.CampaignID)
+
+	if err != nil {
+		return GoodsPledge{}, err
+	}
+
+	if !campaign.IsLive() {
+		return GoodsPledge{}, errors.New("CAMPAIGN IS NOT ACCEPTING DONATIONS")
+	}
+
+	if input.DeliveryMethod == DELIVERY_PICKUP && input.PickupDate.Before(time.Now()) {
+		return GoodsPledge{}, errors.New("PICKUP DATE CANNOT BE IN THE PAST")
+	}
+
+	pledge := GoodsPledge{}
+	pledge.ItemID = item.ID
+	pledge.CampaignID = item.CampaignID
+	pledge.UserID = input.User.ID
+	pledge.Quantity = input.Quantity
+	pledge.DeliveryMethod = input.DeliveryMethod
+	pledge.ContactPhone = input.ContactPhone
+	pledge.Note = input.Note
+	pledge.Status = STATUS_PLEDGED
+
+	if input.DeliveryMethod == DELIVERY_PICKUP {
+		pledge.PickupAddress = input.PickupAddress
+		pledge.PickupDate = input.PickupDate
+	}
+
+	newPledge, err := s.repository.SavePledge(pledge)
+
+	if err != nil {
+		return newPledge, err
+	}
+
+	err = s.repository.AddItemQuantities(item.ID, newPledge.Quantity, 0)
+
+	if err != nil {
+		return newPledge, err
+	}
+
+	newPledge.Item = item
+	newPledge.Campaign = campaign
+
+	return newPledge, nil
+}
+
+func (s *service) GetUserPledges(userId int) ([]GoodsPledge, error) {
+	pledges, err := s.repository.FindPledgesByUserID(userId)
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+func (s *service) GetCampaignPledges(input GetCampaignPledgesInput) ([]GoodsPledge, error) {
+	campaign, err := s.campaignRepository.FindById(input.ID)
+
+	if err != nil {
+		return []GoodsPledge{}, err
+	}
+
+	if campaign.ID == 0 || campaign.UserID != input.User.ID {
+		return []GoodsPledge{}, errors.New("NOT AUTHORIZATION OF THIS ITEM")
+	}
+
+	pledges, err := s.repository.FindPledgesByCampaignID(campaign.ID)
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+func (s *service) CancelPledge(input GetPledgeDetailInput) (GoodsPledge, error) {
+	pledge, err := s.repository.FindPledgeById(input.ID)
+
+	if err != nil {
+		return pledge, err
+	}
+
+	if pledge.ID == 0 || pledge.UserID != input.User.ID {
+		return pledge, errors.New("PLEDGE NOT FOUND")
+	}
+
+	if pledge.Status != STATUS_PLEDGED {
+		return pledge, errors.New("PLEDGE CAN NO LONGER BE CANCELLED")
+	}
+
+	pledge.Status = STATUS_CANCELLED
+
+	updatedPledge, err := s.repository.UpdatePledge(pledge)
+
+	if err != nil {
+		return updatedPledge, err
+	}
+
+	err = s.repository.AddItemQuantities(pledge.ItemID, -pledge.Quantity, 0)
+
+	if err != nil {
+		return updatedPledge, err
+	}
+
+	return updatedPledge, nil
+}
+
+func (s *service) GetPledges() ([]GoodsPledge, error) {
+	pledges, err := s.repository.FindAllPledges()
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+func (s *service) GetPledgeById(pledgeId int) (GoodsPledge, error) {
+	pledge, err := s.repository.FindPledgeById(pledgeId)
+
+	if err != nil {
+		return pledge, err
+	}
+
+	if pledge.ID == 0 {
+		return pledge, errors.New("PLEDGE NOT FOUND")
+	}
+
+	return pledge, nil
+}
+
+// ReceivePledge is confirmed by whoever got the goods, an admin or the
+// campaign organizer. What was actually handed over may differ from the
+// pledge, only that counts as received.
+func (s *service) ReceivePledge(form FormReceivePledgeInput) (GoodsPledge, error) {
+	pledge, err := s.GetPledgeById(form.ID)
+
+	if err != nil {
+		return pledge, err
+	}
+
+	if pledge.Status != STATUS_PLEDGED {
+		return pledge, errors.New("PLEDGE HAS ALREADY BEEN HANDLED")
+	}
+
+	now := time.Now()
+
+	pledge.Status = STATUS_RECEIVED
+	pledge.ReceivedQuantity = form.ReceivedQuantity
+	pledge.ReceivedByID = form.ReceiverID
+	pledge.ReceivedAt = &now
+
+	updatedPledge, err := s.repository.UpdatePledge(pledge)
+
+	if err != nil {
+		return updatedPledge, err
+	}
+
+	err = s.repository.AddItemQuantities(pledge.ItemID, -pledge.Quantity, pledge.ReceivedQuantity)
+
+	if err != nil {
+		return updatedPledge, err
+	}
+
+	s.notificationService.Notify(notification.NotifyInput{
+		UserID:  pledge.UserID,
+		Title:   "Goods received",
+		Message: fmt.Sprintf("Your %d %s of %s for %s have been received. Thank you!", pledge.ReceivedQuantity, pledge.Item.Unit, pledge.Item.Name, pledge.Campaign.Name),
+		Link:    pledge.Campaign.PublicPath(),
+	})
+
+	return updatedPledge, nil
+}
+
+// ConfirmPledgeReceipt lets the organizer confirm goods handed over at the
+// campaign without going through an admin.
+func (s *service) ConfirmPledgeReceipt(input ConfirmPledgeReceiptInput) (GoodsPledge, error) {
+	pledge, err := s.GetPledgeById(input.ID)
+
+	if err != nil {
+		return pledge, err
+	}
+
+	if pledge.Campaign.UserID != input.User.ID {
+		return pledge, errors.New("NOT AUTHORIZATION OF THIS ITEM")
+	}
+
+	form := FormReceivePledgeInput{}
+	form.ID = pledge.ID
+	form.ReceivedQuantity = input.ReceivedQuantity
+	form.ReceiverID = input.User.ID
+
+	return s.ReceivePledge(form)
+}
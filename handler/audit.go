@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/user"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordAudit stores a mutation made by the authenticated API user. Failing
+// to write the audit log does not fail the request.
+func recordAudit(c *gin.Context, auditService audit.Service, action string, entityType string, entityID int, before interface{}, after interface{}) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	recordAuditAs(c, auditService, currentUser.ID, action, entityType, entityID, before, after)
+}
+
+// recordAuditAs stores a mutation made before the actor is authenticated,
+// such as a registration.
+func recordAuditAs(c *gin.Context, auditService audit.Service, actorID int, action string, entityType string, entityID int, before interface{}, after interface{}) {
+	input := audit.RecordInput{}
+	input.ActorID = actorID
+	input.Action = action
+	input.EntityType = entityType
+	input.EntityID = entityID
+	input.Before = before
+	input.After = after
+	input.IPAddress = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	_, err := auditService.Record(input)
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+}
@@ -1,28 +1,45 @@
 package handler
 
 import (
+	"bekasiberbagi/appurl"
+	"bekasiberbagi/audit"
 	"bekasiberbagi/campaign"
 	"bekasiberbagi/response"
 	"bekasiberbagi/user"
 	"fmt"
 	"net/http"
-	"strconv"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type CampaignHandler struct {
-	service campaign.Service
+	service      campaign.Service
+	auditService audit.Service
+	detailLoader campaign.DetailLoader
 }
 
-func NewCampaignHandler(service campaign.Service) *CampaignHandler {
-	return &CampaignHandler{service}
+func NewCampaignHandler(service campaign.Service, auditService audit.Service, detailLoader campaign.DetailLoader) *CampaignHandler {
+	return &CampaignHandler{service, auditService, detailLoader}
 }
 
 func (h *CampaignHandler) GetCampaigns(c *gin.Context) {
-	userId, _ := strconv.Atoi(c.Query("user_id"))
+	var input campaign.GetCampaignsInput
 
-	campaigns, err := h.service.GetCampaigns(userId)
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Invalid campaign filter", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.Viewer = optionalUser(c)
+
+	campaigns, err := h.service.GetCampaigns(input)
 
 	if err != nil {
 		response := response.APIResponseFailed("Get Campaigns failed", http.StatusBadRequest)
@@ -35,6 +52,28 @@ func (h *CampaignHandler) GetCampaigns(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *CampaignHandler) GetNearbyCampaigns(c *gin.Context) {
+	var input campaign.GetNearbyCampaignsInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Invalid location", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	campaigns, err := h.service.GetNearbyCampaigns(input)
+
+	if err != nil {
+		response := response.APIResponseFailed("Get nearby campaigns failed", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of nearby campaigns", http.StatusOK, campaign.FormatCampaigns(campaigns))
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *CampaignHandler) GetCampaign(c *gin.Context) {
 	var input campaign.GetCampaignDetailInput
 
@@ -45,7 +84,20 @@ func (h *CampaignHandler) GetCampaign(c *gin.Context) {
 		return
 	}
 
-	campaignDetail, err := h.service.GetCampaignById(input)
+	campaignDetail, err := h.service.GetVisibleCampaign(input, optionalUser(c))
+	if err == campaign.ErrCampaignNotFound {
+		response := response.APIResponseFailed("Campaign not found", http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	if err != nil {
+		response := response.APIResponseFailed("Error when get detail", http.StatusInternalServerError)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	err = h.detailLoader.LoadDetails(&campaignDetail)
 	if err != nil {
 		response := response.APIResponseFailed("Error when get detail", http.StatusBadRequest)
 		c.JSON(http.StatusBadRequest, response)
@@ -77,6 +129,8 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "campaign", campaignCreated.ID, nil, campaignCreated)
+
 	response := response.APIResponseSuccess("Campaign detail", http.StatusOK, campaign.FormatCampaign(campaignCreated))
 	c.JSON(http.StatusOK, response)
 }
@@ -103,6 +157,8 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 
 	input.User = c.MustGet("currentUser").(user.User)
 
+	campaignExists, _ := h.service.GetCampaignById(inputUri)
+
 	campaignCreated, err := h.service.UpdateCampaign(inputUri, input)
 
 	if err != nil {
@@ -112,10 +168,49 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", campaignCreated.ID, campaignExists, campaignCreated)
+
 	response := response.APIResponseSuccess("Update campaign success", http.StatusOK, campaign.FormatCampaign(campaignCreated))
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *CampaignHandler) UpdateStretchGoals(c *gin.Context) {
+	var inputUri campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Update stretch goals failed coz error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.UpdateStretchGoalsInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Update stretch goals failed coz input", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	campaignExists, _ := h.service.GetCampaignById(inputUri)
+
+	updatedCampaign, err := h.service.UpdateStretchGoals(inputUri, input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Update stretch goals failed coz service", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", updatedCampaign.ID, stretchGoalsAudit(campaignExists.StretchGoals), stretchGoalsAudit(updatedCampaign.StretchGoals))
+
+	response := response.APIResponseSuccess("Update stretch goals success", http.StatusOK, campaign.FormatCampaignDetail(updatedCampaign))
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *CampaignHandler) CreateCampaignImage(c *gin.Context) {
 	file, err := c.FormFile("campaign_image")
 
@@ -153,7 +248,7 @@ func (h *CampaignHandler) CreateCampaignImage(c *gin.Context) {
 		return
 	}
 
-	_, err = h.service.CreateCampaignImage(createCampaignImageInput, path)
+	campaignImage, err := h.service.CreateCampaignImage(createCampaignImageInput, path)
 	if err != nil {
 		data := gin.H{"is_uploaded": false}
 		response := response.APIResponseFailedWithData(err.Error(), http.StatusBadRequest, data)
@@ -161,7 +256,245 @@ func (h *CampaignHandler) CreateCampaignImage(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "campaign_image", campaignImage.ID, nil, campaignImage)
+
 	data := gin.H{"is_uploaded": true}
 	response := response.APIResponseSuccess("Success upload campaign image", http.StatusOK, data)
 	c.JSON(http.StatusOK, response)
 }
+
+func (h *CampaignHandler) UploadCampaignDocument(c *gin.Context) {
+	var inputUri campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.CreateCampaignDocumentInput
+
+	err = c.ShouldBind(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Upload document failed coz input", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	file, err := c.FormFile("document")
+	if err != nil {
+		data := gin.H{"is_uploaded": false}
+		response := response.APIResponseFailedWithData(err.Error(), http.StatusBadRequest, data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if !campaign.IsAllowedDocument(file.Filename) {
+		data := gin.H{"is_uploaded": false}
+		response := response.APIResponseFailedWithData("Document must be a pdf, jpg or png file", http.StatusBadRequest, data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err = os.MkdirAll(campaign.DOCUMENT_STORAGE_PATH, 0700)
+	if err != nil {
+		data := gin.H{"is_uploaded": false}
+		response := response.APIResponseFailedWithData(err.Error(), http.StatusInternalServerError, data)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	path := fmt.Sprintf("%s/%d-%d%s", campaign.DOCUMENT_STORAGE_PATH, input.CampaignID, time.Now().UnixNano(), strings.ToLower(filepath.Ext(file.Filename)))
+
+	err = c.SaveUploadedFile(file, path)
+	if err != nil {
+		data := gin.H{"is_uploaded": false}
+		response := response.APIResponseFailedWithData(err.Error(), http.StatusBadRequest, data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	document, err := h.service.CreateDocument(input, path, filepath.Base(file.Filename))
+	if err != nil {
+		os.Remove(path)
+
+		data := gin.H{"is_uploaded": false}
+		response := response.APIResponseFailedWithData(err.Error(), http.StatusBadRequest, data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "campaign_document", document.ID, nil, document)
+
+	data := gin.H{"is_uploaded": true}
+	response := response.APIResponseSuccess("Success upload campaign document", http.StatusOK, data)
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CampaignHandler) GetCampaignRevisions(c *gin.Context) {
+	var input campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	_, err = h.service.GetVisibleCampaign(input, optionalUser(c))
+	if err != nil {
+		response := response.APIResponseFailed("Campaign not found", http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	revisions, err := h.service.GetRevisions(input.ID)
+	if err != nil {
+		response := response.APIResponseFailed("Error when get revisions", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Campaign revisions", http.StatusOK, campaign.FormatCampaignRevisions(revisions))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CampaignHandler) GetCampaignBySlug(c *gin.Context) {
+	var input campaign.GetCampaignBySlugInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	campaignDetail, moved, err := h.service.GetCampaignBySlug(input)
+	if err == campaign.ErrCampaignNotFound {
+		response := response.APIResponseFailed("Campaign not found", http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	if err != nil {
+		response := response.APIResponseFailed("Error when get detail", http.StatusInternalServerError)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if moved {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/campaigns/by-slug/"+campaignDetail.Slug)
+		return
+	}
+
+	err = h.detailLoader.LoadDetails(&campaignDetail)
+	if err != nil {
+		response := response.APIResponseFailed("Error when get detail", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Campaign detail", http.StatusOK, campaign.FormatCampaignDetail(campaignDetail))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CampaignHandler) GetCampaignQRCode(c *gin.Context) {
+	var inputUri campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.GetCampaignQRCodeInput
+
+	err = c.ShouldBindQuery(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Error query", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	campaignDetail, err := h.service.GetCampaignById(inputUri)
+	if err != nil || campaignDetail.ID == 0 || !campaignDetail.IsLive() {
+		response := response.APIResponseFailed("Campaign not found", http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	if input.Size < 128 || input.Size > 2048 {
+		input.Size = 512
+	}
+
+	query := url.Values{}
+
+	if input.UtmSource != "" {
+		query.Set("utm_source", input.UtmSource)
+	}
+
+	if input.UtmMedium != "" {
+		query.Set("utm_medium", input.UtmMedium)
+	}
+
+	if input.UtmCampaign != "" {
+		query.Set("utm_campaign", input.UtmCampaign)
+	}
+
+	donationURL := appurl.Absolute(c, campaignDetail.PublicPath())
+
+	if len(query) > 0 {
+		donationURL = donationURL + "?" + query.Encode()
+	}
+
+	if input.Format == "svg" {
+		svg, err := campaign.GenerateQRCodeSVG(donationURL, input.Size)
+		if err != nil {
+			response := response.APIResponseFailed(err.Error(), http.StatusInternalServerError)
+			c.JSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		c.Data(http.StatusOK, "image/svg+xml", []byte(svg))
+		return
+	}
+
+	png, err := campaign.GenerateQRCodePNG(donationURL, input.Size)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusInternalServerError)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func (h *CampaignHandler) GetCategories(c *gin.Context) {
+	categories, err := h.service.GetCategoriesWithCount(campaign.STATUS_APPROVED)
+
+	if err != nil {
+		response := response.APIResponseFailed("Get Categories failed", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of categories", http.StatusOK, campaign.FormatCategoryCounts(categories))
+	c.JSON(http.StatusOK, response)
+}
+
+// stretchGoalsAudit lists the goals as one line of text, the audit log only
+// diffs the top level scalar fields of an object.
+func stretchGoalsAudit(stretchGoals []campaign.CampaignStretchGoal) gin.H {
+	goals := []string{}
+
+	for _, stretchGoal := range stretchGoals {
+		goals = append(goals, fmt.Sprintf("%d %s: %s", stretchGoal.Amount, stretchGoal.Title, stretchGoal.Description))
+	}
+
+	return gin.H{"StretchGoals": strings.Join(goals, "; ")}
+}
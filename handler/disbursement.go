@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/disbursement"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type disbursementHandler struct {
+	service      disbursement.Service
+	auditService audit.Service
+}
+
+func NewDisbursementHandler(service disbursement.Service, auditService audit.Service) *disbursementHandler {
+	return &disbursementHandler{service, auditService}
+}
+
+func (h *disbursementHandler) RequestDisbursement(c *gin.Context) {
+	var inputUri disbursement.GetCampaignDisbursementInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input disbursement.CreateDisbursementInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Request disbursement failed coz input", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newDisbursement, err := h.service.RequestDisbursement(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Request disbursement failed coz service", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "disbursement", newDisbursement.ID, nil, newDisbursement)
+
+	response := response.APIResponseSuccess("Request disbursement success", http.StatusOK, disbursement.FormatDisbursement(newDisbursement))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *disbursementHandler) GetCampaignDisbursements(c *gin.Context) {
+	var input disbursement.GetCampaignDisbursementInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	disbursements, err := h.service.GetCampaignDisbursements(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of disbursements", http.StatusOK, disbursement.FormatDisbursements(disbursements))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *disbursementHandler) GetCampaignBalance(c *gin.Context) {
+	var input campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	balance, err := h.service.GetBalance(input.ID)
+	if err != nil {
+		response := response.APIResponseFailed("Get balance failed", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Campaign balance", http.StatusOK, disbursement.FormatBalance(balance))
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/expense"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type expenseHandler struct {
+	service      expense.Service
+	auditService audit.Service
+}
+
+func NewExpenseHandler(service expense.Service, auditService audit.Service) *expenseHandler {
+	return &expenseHandler{service, auditService}
+}
+
+func (h *expenseHandler) CreateExpense(c *gin.Context) {
+	var inputUri expense.GetCampaignExpensesInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input expense.CreateExpenseInput
+
+	err = c.ShouldBind(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Create expense failed coz input", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	for _, file := range form.File["receipts"] {
+		if !expense.IsAllowedReceipt(file.Filename) {
+			response := response.APIResponseFailed("Receipts must be pdf, jpg or png files", http.StatusBadRequest)
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+	}
+
+	err = os.MkdirAll(expense.RECEIPT_STORAGE_PATH, 0755)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusInternalServerError)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	var paths []string
+
+	for i, file := range form.File["receipts"] {
+		path := fmt.Sprintf("%s/%d-%d-%d%s", expense.RECEIPT_STORAGE_PATH, input.CampaignID, time.Now().UnixNano(), i, strings.ToLower(filepath.Ext(file.Filename)))
+
+		err = c.SaveUploadedFile(file, path)
+		if err != nil {
+			removeFiles(paths)
+
+			response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+
+		paths = append(paths, path)
+	}
+
+	newExpense, err := h.service.CreateExpense(input, paths)
+	if err != nil {
+		removeFiles(paths)
+
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Create expense failed coz service", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "expense", newExpense.ID, nil, newExpense)
+
+	response := response.APIResponseSuccess("Create expense success", http.StatusOK, expense.FormatExpense(newExpense))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *expenseHandler) GetCampaignExpenses(c *gin.Context) {
+	var input expense.GetCampaignExpensesInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	expenses, err := h.service.GetExpenses(input)
+	if err != nil {
+		response := response.APIResponseFailed("Get expenses failed", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of expenses", http.StatusOK, expense.FormatExpenses(expenses))
+	c.JSON(http.StatusOK, response)
+}
+
+func removeFiles(paths []string) {
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
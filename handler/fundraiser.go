@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/fundraiser"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fundraiserHandler struct {
+	service      fundraiser.Service
+	auditService audit.Service
+}
+
+func NewFundraiserHandler(service fundraiser.Service, auditService audit.Service) *fundraiserHandler {
+	return &fundraiserHandler{service, auditService}
+}
+
+func (h *fundraiserHandler) CreateFundraiser(c *gin.Context) {
+	var inputUri fundraiser.GetCampaignFundraisersInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input fundraiser.CreateFundraiserInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Create fundraiser failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newFundraiser, err := h.service.CreateFundraiser(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Create fundraiser failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "fundraiser", newFundraiser.ID, nil, newFundraiser)
+
+	response := response.APIResponseSuccess("Create fundraiser success", http.StatusOK, fundraiser.FormatFundraiser(newFundraiser))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *fundraiserHandler) GetCampaignFundraisers(c *gin.Context) {
+	var input fundraiser.GetCampaignFundraisersInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	fundraisers, err := h.service.GetLeaderboard(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Fundraiser leaderboard", http.StatusOK, fundraiser.FormatFundraisers(fundraisers))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *fundraiserHandler) GetFundraiserBySlug(c *gin.Context) {
+	var input fundraiser.GetFundraiserBySlugInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	fundraiserDetail, err := h.service.GetFundraiserBySlug(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Fundraiser detail", http.StatusOK, fundraiser.FormatFundraiser(fundraiserDetail))
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/goods"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type goodsHandler struct {
+	service      goods.Service
+	auditService audit.Service
+}
+
+func NewGoodsHandler(service goods.Service, auditService audit.Service) *goodsHandler {
+	return &goodsHandler{service, auditService}
+}
+
+func (h *goodsHandler) GetCampaignItems(c *gin.Context) {
+	var input goods.GetCampaignItemsInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	items, err := h.service.GetItems(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of needed items", http.StatusOK, goods.FormatItems(items))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) CreateItem(c *gin.Context) {
+	var inputUri goods.GetCampaignItemsInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input goods.CreateItemInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Create item failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newItem, err := h.service.CreateItem(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Create item failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "goods_item", newItem.ID, nil, newItem)
+
+	response := response.APIResponseSuccess("Create item success", http.StatusOK, goods.FormatItem(newItem))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) CreatePledge(c *gin.Context) {
+	var inputUri goods.GetItemDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input goods.CreatePledgeInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Pledge goods failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.ItemID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newPledge, err := h.service.CreatePledge(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Pledge goods failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "goods_pledge", newPledge.ID, nil, newPledge)
+
+	response := response.APIResponseSuccess("Pledge goods success", http.StatusOK, goods.FormatPledge(newPledge))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) GetUserPledges(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	pledges, err := h.service.GetUserPledges(currentUser.ID)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of goods pledges", http.StatusOK, goods.FormatPledges(pledges))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) GetCampaignPledges(c *gin.Context) {
+	var input goods.GetCampaignPledgesInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	pledges, err := h.service.GetCampaignPledges(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of goods pledges", http.StatusOK, goods.FormatPledges(pledges))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) CancelPledge(c *gin.Context) {
+	var input goods.GetPledgeDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	pledgeExists, _ := h.service.GetPledgeById(input.ID)
+
+	cancelledPledge, err := h.service.CancelPledge(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "goods_pledge", cancelledPledge.ID, pledgeExists, cancelledPledge)
+
+	response := response.APIResponseSuccess("Goods pledge cancelled", http.StatusOK, goods.FormatPledge(cancelledPledge))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *goodsHandler) ConfirmPledgeReceipt(c *gin.Context) {
+	var inputUri goods.GetPledgeDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input goods.ConfirmPledgeReceiptInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Confirm goods receipt failed coz input", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.ID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	pledgeExists, _ := h.service.GetPledgeById(input.ID)
+
+	receivedPledge, err := h.service.ConfirmPledgeReceipt(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "goods_pledge", receivedPledge.ID, pledgeExists, receivedPledge)
+
+	response := response.APIResponseSuccess("Goods have been marked as received", http.StatusOK, goods.FormatPledge(receivedPledge))
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/notification"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type notificationHandler struct {
+	service      notification.Service
+	auditService audit.Service
+}
+
+func NewNotificationHandler(service notification.Service, auditService audit.Service) *notificationHandler {
+	return &notificationHandler{service, auditService}
+}
+
+func (h *notificationHandler) GetNotifications(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	notifications, err := h.service.GetNotifications(currentUser.ID)
+	if err != nil {
+		response := response.APIResponseFailed("Get notifications failed", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of notifications", http.StatusOK, notification.FormatNotifications(notifications))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *notificationHandler) MarkAsRead(c *gin.Context) {
+	var input notification.GetNotificationDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	notificationExists, _ := h.service.GetUserNotification(input)
+
+	readNotification, err := h.service.MarkAsRead(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "notification", readNotification.ID, notificationExists, readNotification)
+
+	response := response.APIResponseSuccess("Notification marked as read", http.StatusOK, notification.FormatNotification(readNotification))
+	c.JSON(http.StatusOK, response)
+}
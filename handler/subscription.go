@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/response"
+	"bekasiberbagi/subscription"
+	"bekasiberbagi/user"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type subscriptionHandler struct {
+	service      subscription.Service
+	auditService audit.Service
+}
+
+func NewSubscriptionHandler(service subscription.Service, auditService audit.Service) *subscriptionHandler {
+	return &subscriptionHandler{service, auditService}
+}
+
+func (h *subscriptionHandler) GetSubscriptions(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	subscriptions, err := h.service.GetUserSubscriptions(currentUser.ID)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of subscriptions", http.StatusOK, subscription.FormatSubscriptions(subscriptions))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *subscriptionHandler) CreateSubscription(c *gin.Context) {
+	var input subscription.CreateSubscriptionInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Create subscription failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newSubscription, err := h.service.CreateSubscription(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Create subscription failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "subscription", newSubscription.ID, nil, newSubscription)
+
+	response := response.APIResponseSuccess("Create subscription success", http.StatusOK, subscription.FormatSubscription(newSubscription))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *subscriptionHandler) PauseSubscription(c *gin.Context) {
+	h.changeStatus(c, "Subscription paused", h.service.PauseSubscription)
+}
+
+func (h *subscriptionHandler) ResumeSubscription(c *gin.Context) {
+	h.changeStatus(c, "Subscription resumed", h.service.ResumeSubscription)
+}
+
+func (h *subscriptionHandler) CancelSubscription(c *gin.Context) {
+	h.changeStatus(c, "Subscription cancelled", h.service.CancelSubscription)
+}
+
+func (h *subscriptionHandler) changeStatus(c *gin.Context, message string, change func(input subscription.GetSubscriptionDetailInput) (subscription.Subscription, error)) {
+	var input subscription.GetSubscriptionDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	subscriptionExists, _ := h.service.GetUserSubscription(input)
+
+	updatedSubscription, err := change(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "subscription", updatedSubscription.ID, subscriptionExists, updatedSubscription)
+
+	response := response.APIResponseSuccess(message, http.StatusOK, subscription.FormatSubscription(updatedSubscription))
+	c.JSON(http.StatusOK, response)
+}
@@ -1,20 +1,23 @@
 package handler
 
 import (
+	"bekasiberbagi/audit"
 	"bekasiberbagi/response"
 	"bekasiberbagi/transaction"
 	"bekasiberbagi/user"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 type transactionHandler struct {
-	service transaction.Service
+	service      transaction.Service
+	auditService audit.Service
 }
 
-func NewTransactionHandler(service transaction.Service) *transactionHandler {
-	return &transactionHandler{service}
+func NewTransactionHandler(service transaction.Service, auditService audit.Service) *transactionHandler {
+	return &transactionHandler{service, auditService}
 }
 
 func (h *transactionHandler) GetCampaignTransaction(c *gin.Context) {
@@ -82,10 +85,33 @@ func (h *transactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "transaction", newTransaction.ID, nil, newTransaction)
+
 	response := response.APIResponseSuccess("Create transaction success", http.StatusOK, transaction.FormatTransaction(newTransaction))
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *transactionHandler) GetFee(c *gin.Context) {
+	var input transaction.GetFeeInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Calculate fee failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	fee, err := h.service.CalculateFee(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Transaction fee", http.StatusOK, transaction.FormatFee(fee))
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *transactionHandler) PaymentNotification(c *gin.Context) {
 	var input transaction.TransactionNotificationInput
 
@@ -105,3 +131,40 @@ func (h *transactionHandler) PaymentNotification(c *gin.Context) {
 
 	c.JSON(http.StatusOK, input)
 }
+
+func (h *transactionHandler) GetUserStatement(c *gin.Context) {
+	var input transaction.GetStatementInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	statement, err := h.service.GetStatement(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if c.Query("format") == "pdf" {
+		pdf, err := transaction.GenerateStatementPdf(statement)
+		if err != nil {
+			response := response.APIResponseFailed(err.Error(), http.StatusInternalServerError)
+			c.JSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		fileName := fmt.Sprintf("statement-%d.pdf", statement.Year)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+		c.Data(http.StatusOK, "application/pdf", pdf)
+		return
+	}
+
+	response := response.APIResponseSuccess("Donation statement", http.StatusOK, transaction.FormatStatement(statement))
+	c.JSON(http.StatusOK, response)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bekasiberbagi/audit"
 	"bekasiberbagi/auth"
 	"bekasiberbagi/response"
 	"bekasiberbagi/user"
@@ -11,12 +12,13 @@ import (
 )
 
 type userHandler struct {
-	userService user.Service
-	authService auth.Service
+	userService  user.Service
+	authService  auth.Service
+	auditService audit.Service
 }
 
-func NewUserHandler(userService user.Service, authService auth.Service) *userHandler {
-	return &userHandler{userService, authService}
+func NewUserHandler(userService user.Service, authService auth.Service, auditService audit.Service) *userHandler {
+	return &userHandler{userService, authService, auditService}
 }
 
 func (h *userHandler) RegisterUser(c *gin.Context) {
@@ -39,6 +41,8 @@ func (h *userHandler) RegisterUser(c *gin.Context) {
 		return
 	}
 
+	recordAuditAs(c, h.auditService, newUser.ID, audit.ACTION_CREATE, "user", newUser.ID, nil, newUser)
+
 	token, err := h.authService.GenerateToken(newUser.ID)
 	if err != nil {
 		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
@@ -134,7 +138,7 @@ func (h *userHandler) UploadAvatar(c *gin.Context) {
 		return
 	}
 
-	_, err = h.userService.SaveAvatar(userId, path)
+	updatedUser, err := h.userService.SaveAvatar(userId, path)
 	if err != nil {
 		data := gin.H{"is_uploaded": false}
 		response := response.APIResponseFailedWithData(err.Error(), http.StatusBadRequest, data)
@@ -142,6 +146,8 @@ func (h *userHandler) UploadAvatar(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "user", updatedUser.ID, currentUser, updatedUser)
+
 	data := gin.H{"is_uploaded": true}
 	response := response.APIResponseSuccess("Success upload avatar", http.StatusOK, data)
 	c.JSON(http.StatusOK, response)
@@ -156,3 +162,12 @@ func (h *userHandler) FetchUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// optionalUser returns the user set by optionalAuthMiddleware, or an empty
+// user for guests.
+func optionalUser(c *gin.Context) user.User {
+	currentUser, _ := c.Get("currentUser")
+	viewer, _ := currentUser.(user.User)
+
+	return viewer
+}
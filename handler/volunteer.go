@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/response"
+	"bekasiberbagi/user"
+	"bekasiberbagi/volunteer"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type volunteerHandler struct {
+	service      volunteer.Service
+	auditService audit.Service
+}
+
+func NewVolunteerHandler(service volunteer.Service, auditService audit.Service) *volunteerHandler {
+	return &volunteerHandler{service, auditService}
+}
+
+func (h *volunteerHandler) GetCampaignOpportunities(c *gin.Context) {
+	var input volunteer.GetCampaignOpportunitiesInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	opportunities, err := h.service.GetOpportunities(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of volunteer opportunities", http.StatusOK, volunteer.FormatOpportunities(opportunities))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *volunteerHandler) CreateOpportunity(c *gin.Context) {
+	var inputUri volunteer.GetCampaignOpportunitiesInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input volunteer.CreateOpportunityInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Create volunteer opportunity failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.CampaignID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newOpportunity, err := h.service.CreateOpportunity(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Create volunteer opportunity failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "volunteer_opportunity", newOpportunity.ID, nil, newOpportunity)
+
+	response := response.APIResponseSuccess("Create volunteer opportunity success", http.StatusOK, volunteer.FormatOpportunity(newOpportunity))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *volunteerHandler) GetOpportunityCalendar(c *gin.Context) {
+	var input volunteer.GetOpportunityDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	opportunity, err := h.service.GetOpportunityById(input.ID)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusNotFound)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=volunteer-%d.ics", opportunity.ID))
+	c.Data(http.StatusOK, volunteer.ICAL_CONTENT_TYPE, volunteer.GenerateICal([]volunteer.VolunteerOpportunity{opportunity}, time.Now()))
+}
+
+func (h *volunteerHandler) SignUp(c *gin.Context) {
+	var inputUri volunteer.GetOpportunityDetailInput
+
+	err := c.ShouldBindUri(&inputUri)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input volunteer.CreateSignupInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Volunteer sign up failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.OpportunityID = inputUri.ID
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newSignup, err := h.service.SignUp(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Volunteer sign up failed", http.StatusUnprocessableEntity, data)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "volunteer_signup", newSignup.ID, nil, newSignup)
+
+	message := "Volunteer sign up success"
+
+	if newSignup.Status == volunteer.STATUS_WAITLISTED {
+		message = "Opportunity is full, you are on the waitlist"
+	}
+
+	response := response.APIResponseSuccess(message, http.StatusOK, volunteer.FormatSignup(newSignup))
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *volunteerHandler) GetUserSignups(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	signups, err := h.service.GetUserSignups(currentUser.ID)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("List of volunteer signups", http.StatusOK, volunteer.FormatSignups(signups))
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUserCalendar exports the shifts the volunteer is confirmed for.
+func (h *volunteerHandler) GetUserCalendar(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	signups, err := h.service.GetUserSignups(currentUser.ID)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	opportunities := []volunteer.VolunteerOpportunity{}
+
+	for _, signup := range signups {
+		if signup.Status == volunteer.STATUS_CONFIRMED {
+			opportunities = append(opportunities, signup.Opportunity)
+		}
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=volunteer.ics")
+	c.Data(http.StatusOK, volunteer.ICAL_CONTENT_TYPE, volunteer.GenerateICal(opportunities, time.Now()))
+}
+
+func (h *volunteerHandler) CancelSignup(c *gin.Context) {
+	var input volunteer.GetSignupDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		response := response.APIResponseFailed("Error uri", http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	signupExists, _ := h.service.GetSignupById(input.ID)
+
+	cancelledSignup, err := h.service.CancelSignup(input)
+	if err != nil {
+		response := response.APIResponseFailed(err.Error(), http.StatusBadRequest)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "volunteer_signup", cancelledSignup.ID, signupExists, cancelledSignup)
+
+	response := response.APIResponseSuccess("Volunteer signup cancelled", http.StatusOK, volunteer.FormatSignup(cancelledSignup))
+	c.JSON(http.StatusOK, response)
+}
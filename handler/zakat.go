@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"bekasiberbagi/response"
+	"bekasiberbagi/zakat"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type zakatHandler struct {
+	service zakat.Service
+}
+
+func NewZakatHandler(service zakat.Service) *zakatHandler {
+	return &zakatHandler{service}
+}
+
+func (h *zakatHandler) Calculate(c *gin.Context) {
+	var input zakat.CalculateInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		response := response.APIResponseValidationFailed("Calculate zakat failed", http.StatusUnprocessableEntity, err)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	calculation, err := h.service.Calculate(input)
+	if err != nil {
+		data := gin.H{"error": err.Error()}
+		response := response.APIResponseFailedWithData("Calculate zakat failed", http.StatusServiceUnavailable, data)
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	response := response.APIResponseSuccess("Zakat calculation", http.StatusOK, zakat.FormatCalculation(calculation))
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,54 @@
+package ledger
+
+import "time"
+
+const ACCOUNT_CASH = "cash"
+const ACCOUNT_CAMPAIGN_FUNDS = "campaign_funds"
+const ACCOUNT_PLATFORM_FEES = "platform_fees"
+
+const JOURNAL_DONATION = "donation"
+const JOURNAL_FEE = "fee"
+const JOURNAL_REFUND = "refund"
+const JOURNAL_DISBURSEMENT = "disbursement"
+
+const REFERENCE_TRANSACTION = "transaction"
+const REFERENCE_DISBURSEMENT = "disbursement"
+
+// LedgerJournal groups the balanced entries of one money movement, for example a
+// paid donation or an approved disbursement.
+type LedgerJournal struct {
+	ID            int
+	CampaignID    int
+	Type          string
+	ReferenceType string
+	ReferenceID   int
+	Description   string
+	CreatedAt     time.Time
+	Entries       []LedgerEntry `gorm:"foreignKey:JournalID"`
+}
+
+type LedgerEntry struct {
+	ID         int
+	JournalID  int
+	CampaignID int
+	Account    string
+	Debit      int
+	Credit     int
+	CreatedAt  time.Time
+}
+
+type TypeTotal struct {
+	Type   string
+	Amount int
+}
+
+// Balance is the state of the campaign funds account. Donations increase it
+// while fees, refunds and disbursements reduce what is left to pay out.
+type Balance struct {
+	CampaignID int
+	Raised     int
+	Fees       int
+	Refunded   int
+	Disbursed  int
+	Available  int
+}
@@ -0,0 +1,80 @@
+package ledger
+
+type Line struct {
+	Account string
+	Debit   int
+	Credit  int
+}
+
+type PostInput struct {
+	CampaignID    int
+	Type          string
+	ReferenceType string
+	ReferenceID   int
+	Description   string
+	Lines         []Line
+}
+
+// DonationInput books the money received from the donor. The tip is platform
+// income and never reaches the campaign funds.
+func DonationInput(campaignId int, transactionId int, gross int, tip int) PostInput {
+	return PostInput{
+		CampaignID:    campaignId,
+		Type:          JOURNAL_DONATION,
+		ReferenceType: REFERENCE_TRANSACTION,
+		ReferenceID:   transactionId,
+		Description:   "Donation received",
+		Lines: []Line{
+			{Account: ACCOUNT_CASH, Debit: gross},
+			{Account: ACCOUNT_CAMPAIGN_FUNDS, Credit: gross - tip},
+			{Account: ACCOUNT_PLATFORM_FEES, Credit: tip},
+		},
+	}
+}
+
+// FeeInput takes the fees out of the campaign funds. The gateway fee is kept
+// by Midtrans and so leaves our cash, the platform fee is our income. The
+// part of the gateway fee charged on the tip is paid from the tip.
+func FeeInput(campaignId int, transactionId int, gatewayFee int, tipGatewayFee int, platformFee int) PostInput {
+	return PostInput{
+		CampaignID:    campaignId,
+		Type:          JOURNAL_FEE,
+		ReferenceType: REFERENCE_TRANSACTION,
+		ReferenceID:   transactionId,
+		Description:   "Gateway and platform fee",
+		Lines: []Line{
+			{Account: ACCOUNT_CAMPAIGN_FUNDS, Debit: gatewayFee - tipGatewayFee + platformFee},
+			{Account: ACCOUNT_PLATFORM_FEES, Debit: tipGatewayFee},
+			{Account: ACCOUNT_CASH, Credit: gatewayFee},
+			{Account: ACCOUNT_PLATFORM_FEES, Credit: platformFee},
+		},
+	}
+}
+
+func RefundInput(campaignId int, transactionId int, amount int) PostInput {
+	return PostInput{
+		CampaignID:    campaignId,
+		Type:          JOURNAL_REFUND,
+		ReferenceType: REFERENCE_TRANSACTION,
+		ReferenceID:   transactionId,
+		Description:   "Donation refunded",
+		Lines: []Line{
+			{Account: ACCOUNT_CAMPAIGN_FUNDS, Debit: amount},
+			{Account: ACCOUNT_CASH, Credit: amount},
+		},
+	}
+}
+
+func DisbursementInput(campaignId int, disbursementId int, amount int) PostInput {
+	return PostInput{
+		CampaignID:    campaignId,
+		Type:          JOURNAL_DISBURSEMENT,
+		ReferenceType: REFERENCE_DISBURSEMENT,
+		ReferenceID:   disbursementId,
+		Description:   "Funds disbursed to beneficiary",
+		Lines: []Line{
+			{Account: ACCOUNT_CAMPAIGN_FUNDS, Debit: amount},
+			{Account: ACCOUNT_CASH, Credit: amount},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package ledger
+
+import "gorm.io/gorm"
+
+type Repository interface {
+	SaveJournal(journal LedgerJournal) (LedgerJournal, error)
+	SaveJournals(journals []LedgerJournal) ([]LedgerJournal, error)
+	JournalExists(journalType string, referenceType string, referenceId int) (bool, error)
+	SumByType(campaignId int) ([]TypeTotal, error)
+	FindJournalsByCampaignID(campaignId int) ([]LedgerJournal, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// SaveJournal creates the journal together with its entries, gorm wraps the
+// association inserts in a single database transaction.
+func (r *repository) SaveJournal(journal LedgerJournal) (LedgerJournal, error) {
+	err := r.db.Create(&journal).Error
+
+	if err != nil {
+		return journal, err
+	}
+
+	return journal, nil
+}
+
+func (r *repository) SaveJournals(journals []LedgerJournal) ([]LedgerJournal, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range journals {
+			err := tx.Create(&journals[i]).Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return journals, err
+	}
+
+	return journals, nil
+}
+
+func (r *repository) JournalExists(journalType string, referenceType string, referenceId int) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&LedgerJournal{}).Where("type = ? AND reference_type = ? AND reference_id = ?", journalType, referenceType, referenceId).Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// SumByType returns the movement of the campaign funds account per journal
+// type, donations as credits and everything else as debits.
+func (r *repository) SumByType(campaignId int) ([]TypeTotal, error) {
+	var totals []TypeTotal
+
+	err := r.db.Table("ledger_entries").
+		Select("ledger_journals.type AS type, SUM(ledger_entries.credit) - SUM(ledger_entries.debit) AS amount").
+		Joins("JOIN ledger_journals ON ledger_journals.id = ledger_entries.journal_id").
+		Where("ledger_entries.campaign_id = ? AND ledger_entries.account = ?", campaignId, ACCOUNT_CAMPAIGN_FUNDS).
+		Group("ledger_journals.type").
+		Scan(&totals).Error
+
+	if err != nil {
+		return totals, err
+	}
+
+	return totals, nil
+}
+
+func (r *repository) FindJournalsByCampaignID(campaignId int) ([]LedgerJournal, error) {
+	var journals []LedgerJournal
+
+	err := r.db.Where("campaign_id = ?", campaignId).Preload("Entries").Order("id desc").Find(&journals).Error
+
+	if err != nil {
+		return journals, err
+	}
+
+	return journals, nil
+}
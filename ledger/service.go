@@ -0,0 +1,163 @@
+package ledger
+
+import (
+	"errors"
+	"time"
+)
+
+type Service interface {
+	Post(input PostInput) (LedgerJournal, error)
+	PostAll(inputs []PostInput) ([]LedgerJournal, error)
+	Prepare(input PostInput) (LedgerJournal, error)
+	IsPosted(journalType string, referenceType string, referenceId int) (bool, error)
+	GetBalance(campaignId int) (Balance, error)
+	GetJournals(campaignId int) ([]LedgerJournal, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) *service {
+	return &service{repository}
+}
+
+// Post stores a journal after checking that it balances. A journal for the
+// same reference is only posted once, so repeated payment notifications do not
+// count a donation twice.
+func (s *service) Post(input PostInput) (LedgerJournal, error) {
+	journal, err := s.Prepare(input)
+
+	if err != nil {
+		return journal, err
+	}
+
+	newJournal, err := s.repository.SaveJournal(journal)
+
+	if err != nil {
+		return newJournal, err
+	}
+
+	return newJournal, nil
+}
+
+// PostAll stores several journals in one database transaction, none of them
+// is posted when one fails the checks of Post.
+func (s *service) PostAll(inputs []PostInput) ([]LedgerJournal, error) {
+	journals := []LedgerJournal{}
+
+	for _, input := range inputs {
+		journal, err := s.Prepare(input)
+
+		if err != nil {
+			return journals, err
+		}
+
+		journals = append(journals, journal)
+	}
+
+	newJournals, err := s.repository.SaveJournals(journals)
+
+	if err != nil {
+		return newJournals, err
+	}
+
+	return newJournals, nil
+}
+
+// Prepare builds a journal with the checks of Post but does not save it. It
+// is meant for callers that store the journal in the same database
+// transaction as the change that moved the money.
+func (s *service) Prepare(input PostInput) (LedgerJournal, error) {
+	debit := 0
+	credit := 0
+
+	for _, line := range input.Lines {
+		if line.Debit < 0 || line.Credit < 0 {
+			return LedgerJournal{}, errors.New("LEDGER AMOUNT CANNOT BE NEGATIVE")
+		}
+
+		debit += line.Debit
+		credit += line.Credit
+	}
+
+	if debit == 0 || debit != credit {
+		return LedgerJournal{}, errors.New("LEDGER JOURNAL IS NOT BALANCED")
+	}
+
+	exists, err := s.repository.JournalExists(input.Type, input.ReferenceType, input.ReferenceID)
+
+	if err != nil {
+		return LedgerJournal{}, err
+	}
+
+	if exists {
+		return LedgerJournal{}, errors.New("LEDGER JOURNAL ALREADY POSTED")
+	}
+
+	journal := LedgerJournal{}
+	journal.CampaignID = input.CampaignID
+	journal.Type = input.Type
+	journal.ReferenceType = input.ReferenceType
+	journal.ReferenceID = input.ReferenceID
+	journal.Description = input.Description
+	journal.CreatedAt = time.Now()
+
+	for _, line := range input.Lines {
+		if line.Debit == 0 && line.Credit == 0 {
+			continue
+		}
+
+		entry := LedgerEntry{}
+		entry.CampaignID = input.CampaignID
+		entry.Account = line.Account
+		entry.Debit = line.Debit
+		entry.Credit = line.Credit
+		entry.CreatedAt = journal.CreatedAt
+
+		journal.Entries = append(journal.Entries, entry)
+	}
+
+	return journal, nil
+}
+
+func (s *service) IsPosted(journalType string, referenceType string, referenceId int) (bool, error) {
+	return s.repository.JournalExists(journalType, referenceType, referenceId)
+}
+
+func (s *service) GetBalance(campaignId int) (Balance, error) {
+	balance := Balance{CampaignID: campaignId}
+
+	totals, err := s.repository.SumByType(campaignId)
+
+	if err != nil {
+		return balance, err
+	}
+
+	for _, total := range totals {
+		switch total.Type {
+		case JOURNAL_DONATION:
+			balance.Raised = total.Amount
+		case JOURNAL_FEE:
+			balance.Fees = -total.Amount
+		case JOURNAL_REFUND:
+			balance.Refunded = -total.Amount
+		case JOURNAL_DISBURSEMENT:
+			balance.Disbursed = -total.Amount
+		}
+
+		balance.Available += total.Amount
+	}
+
+	return balance, nil
+}
+
+func (s *service) GetJournals(campaignId int) ([]LedgerJournal, error) {
+	journals, err := s.repository.FindJournalsByCampaignID(campaignId)
+
+	if err != nil {
+		return journals, err
+	}
+
+	return journals, nil
+}
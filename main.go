@@ -1,18 +1,38 @@
 package main
 
 import (
+	"bekasiberbagi/audit"
 	"bekasiberbagi/auth"
+	"bekasiberbagi/beneficiary"
 	"bekasiberbagi/campaign"
+	"bekasiberbagi/dashboard"
+	"bekasiberbagi/disbursement"
+	"bekasiberbagi/expense"
+	"bekasiberbagi/fundraiser"
+	"bekasiberbagi/goods"
 	"bekasiberbagi/handler"
+	"bekasiberbagi/ledger"
+	"bekasiberbagi/matching"
+	"bekasiberbagi/milestone"
+	"bekasiberbagi/notification"
 	"bekasiberbagi/payment"
 	"bekasiberbagi/response"
+	"bekasiberbagi/subscription"
 	"bekasiberbagi/transaction"
 	"bekasiberbagi/user"
+	"bekasiberbagi/volunteer"
+	"bekasiberbagi/zakat"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	webHandler "bekasiberbagi/web/handler"
 
@@ -50,21 +70,87 @@ func main() {
 	userRepository := user.NewRepository(db)
 	campaignRepository := campaign.NewRepository(db)
 	transactionRepository := transaction.NewRepository(db)
+	auditRepository := audit.NewRepository(db)
+	notificationRepository := notification.NewRepository(db)
+	ledgerRepository := ledger.NewRepository(db)
+	disbursementRepository := disbursement.NewRepository(db)
+	expenseRepository := expense.NewRepository(db)
+	subscriptionRepository := subscription.NewRepository(db)
+	matchingRepository := matching.NewRepository(db)
+	fundraiserRepository := fundraiser.NewRepository(db)
+	goodsRepository := goods.NewRepository(db)
+	volunteerRepository := volunteer.NewRepository(db)
+	beneficiaryRepository := beneficiary.NewRepository(db)
 
 	userService := user.NewService(userRepository)
 	authService := auth.NewService()
-	campaignService := campaign.NewService(campaignRepository)
+	campaignReapprovalOnEdit, _ := strconv.ParseBool(os.Getenv("CAMPAIGN_REAPPROVAL_ON_EDIT"))
+	campaignService := campaign.NewService(campaignRepository, campaignReapprovalOnEdit, documentURLSecret())
+	backfillCampaignStatus(campaignService)
+	ensureUniqueSlugs(campaignService)
 	paymentService := payment.NewService()
-	transactionService := transaction.NewService(transactionRepository, campaignRepository, paymentService)
+	ledgerService := ledger.NewService(ledgerRepository)
+	matchingService := matching.NewService(matchingRepository, campaignRepository)
+	notificationService := notification.NewService(notificationRepository)
+	milestoneService := milestone.NewService(campaignRepository, notificationService)
+	feeSchedule, err := transaction.ParseFeeSchedule(os.Getenv("PAYMENT_GATEWAY_FEES"), os.Getenv("PLATFORM_FEE_PERCENT"))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
-	userHandler := handler.NewUserHandler(userService, authService)
-	campaignHandler := handler.NewCampaignHandler(campaignService)
-	transactionHandler := handler.NewTransactionHandler(transactionService)
+	transactionService := transaction.NewService(transactionRepository, campaignRepository, fundraiserRepository, paymentService, ledgerService, matchingService, milestoneService, feeSchedule)
+	go backfillLedger(transactionService)
+	disbursementService := disbursement.NewService(disbursementRepository, campaignRepository, ledgerService)
+	expenseService := expense.NewService(expenseRepository, campaignRepository, disbursementService)
+	dashboardService := dashboard.NewService(transactionRepository, campaignRepository, userRepository)
+	fundraiserService := fundraiser.NewService(fundraiserRepository, campaignRepository)
+	goodsService := goods.NewService(goodsRepository, campaignRepository, notificationService)
+	volunteerService := volunteer.NewService(volunteerRepository, campaignRepository, notificationService)
+	beneficiaryService := beneficiary.NewService(beneficiaryRepository, campaignRepository, beneficiaryNIKSecret())
+	campaignDetailLoader := campaign.NewDetailLoader(expenseService.GetSpendingBreakdown, matchingService.GetMatchingTotals, goodsService.GetGoodsProgress, beneficiaryService.CountReached)
+
+	zakatConfig, err := zakat.ParseConfig(os.Getenv("ZAKAT_GOLD_PRICE_PER_GRAM"), os.Getenv("ZAKAT_NISAB_GOLD_GRAMS"))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
-	userWebHandler := webHandler.NewUserHandler(userService)
-	campaignWebHandler := webHandler.NewCampaignHandler(campaignService, userService)
+	zakatService := zakat.NewService(zakatConfig)
+	subscriptionService := subscription.NewService(subscriptionRepository, campaignRepository, transactionService, notificationService)
+	go chargeSubscriptions(subscriptionService)
+
+	auditRetentionDays, _ := strconv.Atoi(os.Getenv("AUDIT_RETENTION_DAYS"))
+	auditService := audit.NewService(auditRepository, auditRetentionDays)
+	go purgeAuditLogs(auditService)
+
+	userHandler := handler.NewUserHandler(userService, authService, auditService)
+	campaignHandler := handler.NewCampaignHandler(campaignService, auditService, campaignDetailLoader)
+	transactionHandler := handler.NewTransactionHandler(transactionService, auditService)
+	notificationHandler := handler.NewNotificationHandler(notificationService, auditService)
+	disbursementHandler := handler.NewDisbursementHandler(disbursementService, auditService)
+	expenseHandler := handler.NewExpenseHandler(expenseService, auditService)
+	fundraiserHandler := handler.NewFundraiserHandler(fundraiserService, auditService)
+	goodsHandler := handler.NewGoodsHandler(goodsService, auditService)
+	volunteerHandler := handler.NewVolunteerHandler(volunteerService, auditService)
+	zakatHandler := handler.NewZakatHandler(zakatService)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService, auditService)
+
+	userWebHandler := webHandler.NewUserHandler(userService, auditService)
+	campaignWebHandler := webHandler.NewCampaignHandler(campaignService, userService, auditService, disbursementService, matchingService)
+	categoryWebHandler := webHandler.NewCategoryHandler(campaignService, auditService)
+	matchingWebHandler := webHandler.NewMatchingHandler(matchingService, auditService)
+	moderationWebHandler := webHandler.NewModerationHandler(campaignService, notificationService, auditService)
+	disbursementWebHandler := webHandler.NewDisbursementHandler(disbursementService, notificationService, auditService)
 	transactionWebHandler := webHandler.NewTransactionHandler(transactionService)
+	subscriptionWebHandler := webHandler.NewSubscriptionHandler(subscriptionService)
+	fundraiserWebHandler := webHandler.NewFundraiserHandler(fundraiserService, auditService)
+	goodsWebHandler := webHandler.NewGoodsHandler(goodsService, auditService)
+	volunteerWebHandler := webHandler.NewVolunteerHandler(volunteerService, auditService)
+	beneficiaryWebHandler := webHandler.NewBeneficiaryHandler(beneficiaryService, campaignService, userService, auditService)
 	webAuthHandler := webHandler.NewWebAuthHandler(userService)
+	exportWebHandler := webHandler.NewExportHandler(transactionService, campaignService, userService)
+	dashboardWebHandler := webHandler.NewDashboardHandler(dashboardService)
+	auditWebHandler := webHandler.NewAuditHandler(auditService)
+	publicWebHandler := webHandler.NewPublicHandler(campaignService, transactionService, expenseService, fundraiserService, campaignDetailLoader)
 
 	router := gin.Default()
 	router.Use(cors.Default())
@@ -86,20 +172,71 @@ func main() {
 	api.POST("/check-email-availability", userHandler.IsEmailAvailability)
 	api.POST("/avatars", authMiddleware(authService, userService), userHandler.UploadAvatar)
 	api.GET("/users/fetch", authMiddleware(authService, userService), userHandler.FetchUser)
-
-	api.GET("/campaigns", campaignHandler.GetCampaigns)
-	api.GET("/campaigns/:id", campaignHandler.GetCampaign)
+	api.GET("/users/me/statements/:year", authMiddleware(authService, userService), transactionHandler.GetUserStatement)
+
+	api.GET("/campaigns", optionalAuthMiddleware(authService, userService), campaignHandler.GetCampaigns)
+	api.GET("/campaigns/nearby", campaignHandler.GetNearbyCampaigns)
+	api.GET("/campaigns/:id", optionalAuthMiddleware(authService, userService), campaignHandler.GetCampaign)
+	api.GET("/campaigns/by-slug/:slug", campaignHandler.GetCampaignBySlug)
+	api.GET("/campaigns/:id/revisions", optionalAuthMiddleware(authService, userService), campaignHandler.GetCampaignRevisions)
+	api.GET("/campaigns/:id/qr", campaignHandler.GetCampaignQRCode)
 	api.POST("/campaigns", authMiddleware(authService, userService), campaignHandler.CreateCampaign)
 	api.PUT("/campaigns/:id", authMiddleware(authService, userService), campaignHandler.UpdateCampaign)
+	api.PUT("/campaigns/:id/stretch-goals", authMiddleware(authService, userService), campaignHandler.UpdateStretchGoals)
 	api.POST("/campaign-images", authMiddleware(authService, userService), campaignHandler.CreateCampaignImage)
+	api.POST("/campaigns/:id/documents", authMiddleware(authService, userService), campaignHandler.UploadCampaignDocument)
+	api.GET("/campaigns/:id/balance", disbursementHandler.GetCampaignBalance)
+	api.GET("/campaigns/:id/disbursements", authMiddleware(authService, userService), disbursementHandler.GetCampaignDisbursements)
+	api.POST("/campaigns/:id/disbursements", authMiddleware(authService, userService), disbursementHandler.RequestDisbursement)
+	api.GET("/campaigns/:id/expenses", expenseHandler.GetCampaignExpenses)
+	api.POST("/campaigns/:id/expenses", authMiddleware(authService, userService), expenseHandler.CreateExpense)
+	api.GET("/campaigns/:id/fundraisers", fundraiserHandler.GetCampaignFundraisers)
+	api.POST("/campaigns/:id/fundraisers", authMiddleware(authService, userService), fundraiserHandler.CreateFundraiser)
+	api.GET("/fundraisers/:slug", fundraiserHandler.GetFundraiserBySlug)
+	api.GET("/campaigns/:id/items", goodsHandler.GetCampaignItems)
+	api.POST("/campaigns/:id/items", authMiddleware(authService, userService), goodsHandler.CreateItem)
+	api.GET("/campaigns/:id/goods-pledges", authMiddleware(authService, userService), goodsHandler.GetCampaignPledges)
+	api.POST("/items/:id/pledges", authMiddleware(authService, userService), goodsHandler.CreatePledge)
+	api.GET("/goods-pledges", authMiddleware(authService, userService), goodsHandler.GetUserPledges)
+	api.POST("/goods-pledges/:id/cancel", authMiddleware(authService, userService), goodsHandler.CancelPledge)
+	api.POST("/goods-pledges/:id/receive", authMiddleware(authService, userService), goodsHandler.ConfirmPledgeReceipt)
+	api.GET("/campaigns/:id/volunteer-opportunities", volunteerHandler.GetCampaignOpportunities)
+	api.POST("/campaigns/:id/volunteer-opportunities", authMiddleware(authService, userService), volunteerHandler.CreateOpportunity)
+	api.GET("/volunteer-opportunities/:id/calendar.ics", volunteerHandler.GetOpportunityCalendar)
+	api.POST("/volunteer-opportunities/:id/signups", authMiddleware(authService, userService), volunteerHandler.SignUp)
+	api.GET("/volunteer-signups", authMiddleware(authService, userService), volunteerHandler.GetUserSignups)
+	api.GET("/volunteer-signups/calendar.ics", authMiddleware(authService, userService), volunteerHandler.GetUserCalendar)
+	api.POST("/volunteer-signups/:id/cancel", authMiddleware(authService, userService), volunteerHandler.CancelSignup)
+	api.GET("/categories", campaignHandler.GetCategories)
+
+	api.GET("/notifications", authMiddleware(authService, userService), notificationHandler.GetNotifications)
+	api.POST("/notifications/:id/read", authMiddleware(authService, userService), notificationHandler.MarkAsRead)
 
 	api.GET("/campaigns/:id/transactions", authMiddleware(authService, userService), transactionHandler.GetCampaignTransaction)
 	api.GET("/transactions", authMiddleware(authService, userService), transactionHandler.GetUserTransactions)
 	api.POST("/transactions", authMiddleware(authService, userService), transactionHandler.CreateTransaction)
+	api.GET("/transactions/fee", transactionHandler.GetFee)
+	api.POST("/zakat/calculate", zakatHandler.Calculate)
 	api.POST("/transactions/notification", transactionHandler.PaymentNotification)
 
+	api.GET("/subscriptions", authMiddleware(authService, userService), subscriptionHandler.GetSubscriptions)
+	api.POST("/subscriptions", authMiddleware(authService, userService), subscriptionHandler.CreateSubscription)
+	api.POST("/subscriptions/:id/pause", authMiddleware(authService, userService), subscriptionHandler.PauseSubscription)
+	api.POST("/subscriptions/:id/resume", authMiddleware(authService, userService), subscriptionHandler.ResumeSubscription)
+	api.POST("/subscriptions/:id/cancel", authMiddleware(authService, userService), subscriptionHandler.CancelSubscription)
+
+	router.GET("/c/:slug", publicWebHandler.Campaign)
+	router.GET("/c/:slug/share.png", publicWebHandler.ShareImage)
+	router.GET("/f/:slug", publicWebHandler.Fundraiser)
+	router.GET("/embed/campaigns/:id", publicWebHandler.Embed)
+	router.GET("/embed/campaigns/:id/widget.js", publicWebHandler.EmbedScript)
+
 	web := router.Group("/web")
+	web.Use(csrfMiddleware())
+	web.GET("/dashboard", authAdminMiddleware(), dashboardWebHandler.Index)
+
 	web.GET("/users", authAdminMiddleware(), userWebHandler.Index)
+	web.GET("/users/data", authAdminMiddleware(), userWebHandler.Data)
 	web.GET("/users/create", authAdminMiddleware(), userWebHandler.Create)
 	web.POST("/users", authAdminMiddleware(), userWebHandler.Store)
 	web.GET("/users/:id/edit", authAdminMiddleware(), userWebHandler.Edit)
@@ -108,6 +245,7 @@ func main() {
 	web.POST("/users/:id/avatar", authAdminMiddleware(), userWebHandler.UpdateAvatar)
 
 	web.GET("/campaigns", authAdminMiddleware(), campaignWebHandler.Index)
+	web.GET("/campaigns/data", authAdminMiddleware(), campaignWebHandler.Data)
 	web.GET("/campaigns/create", authAdminMiddleware(), campaignWebHandler.Create)
 	web.POST("/campaigns", authAdminMiddleware(), campaignWebHandler.Store)
 	web.GET("/campaigns/:id", authAdminMiddleware(), campaignWebHandler.Show)
@@ -115,8 +253,60 @@ func main() {
 	web.POST("/campaigns/:id", authAdminMiddleware(), campaignWebHandler.Update)
 	web.GET("/campaigns/:id/image", authAdminMiddleware(), campaignWebHandler.FormUploadImage)
 	web.POST("/campaigns/:id/image", authAdminMiddleware(), campaignWebHandler.UploadImage)
+	web.POST("/campaigns/:id/verify", authAdminMiddleware(), campaignWebHandler.Verify)
+	web.POST("/campaigns/:id/matching-pledges", authAdminMiddleware(), matchingWebHandler.Store)
+	web.GET("/documents/:id", authAdminMiddleware(), campaignWebHandler.Document)
+	web.GET("/campaigns/:id/revisions", authAdminMiddleware(), campaignWebHandler.Revisions)
+	web.GET("/campaigns/:id/revisions/compare", authAdminMiddleware(), campaignWebHandler.CompareRevisions)
+	web.POST("/campaigns/:id/revisions/:revision_id/rollback", authAdminMiddleware(), campaignWebHandler.RollbackRevision)
+
+	web.GET("/moderation", authAdminMiddleware(), moderationWebHandler.Index)
+	web.POST("/moderation/:id/approve", authAdminMiddleware(), moderationWebHandler.Approve)
+	web.POST("/moderation/:id/reject", authAdminMiddleware(), moderationWebHandler.Reject)
+
+	web.GET("/categories", authAdminMiddleware(), categoryWebHandler.Index)
+	web.GET("/categories/create", authAdminMiddleware(), categoryWebHandler.Create)
+	web.POST("/categories", authAdminMiddleware(), categoryWebHandler.Store)
+	web.GET("/categories/:id/edit", authAdminMiddleware(), categoryWebHandler.Edit)
+	web.POST("/categories/:id", authAdminMiddleware(), categoryWebHandler.Update)
+	web.POST("/categories/:id/delete", authAdminMiddleware(), categoryWebHandler.Delete)
+
+	web.GET("/goods", authAdminMiddleware(), goodsWebHandler.Index)
+	web.POST("/goods/:id/receive", authAdminMiddleware(), goodsWebHandler.Receive)
+
+	web.GET("/volunteers", authAdminMiddleware(), volunteerWebHandler.Index)
+	web.GET("/volunteers/:id", authAdminMiddleware(), volunteerWebHandler.Show)
+	web.POST("/volunteers/signups/:id/attendance", authAdminMiddleware(), volunteerWebHandler.UpdateAttendance)
+
+	web.GET("/beneficiaries", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Index)
+	web.GET("/beneficiaries/create", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Create)
+	web.GET("/beneficiaries/report", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Report)
+	web.POST("/beneficiaries", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Store)
+	web.GET("/beneficiaries/:id", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Show)
+	web.GET("/beneficiaries/:id/edit", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Edit)
+	web.POST("/beneficiaries/:id", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.Update)
+	web.POST("/beneficiaries/:id/distributions", authAdminMiddleware(), noStoreMiddleware(), beneficiaryWebHandler.StoreDistribution)
+
+	web.GET("/disbursements", authAdminMiddleware(), disbursementWebHandler.Index)
+	web.POST("/disbursements/:id/approve", authAdminMiddleware(), disbursementWebHandler.Approve)
+	web.POST("/disbursements/:id/reject", authAdminMiddleware(), disbursementWebHandler.Reject)
+	web.GET("/disbursements/:id/proof", authAdminMiddleware(), disbursementWebHandler.Proof)
 
 	web.GET("/transactions", authAdminMiddleware(), transactionWebHandler.Index)
+	web.GET("/transactions/data", authAdminMiddleware(), transactionWebHandler.Data)
+	web.GET("/transactions/statements/:year", authAdminMiddleware(), transactionWebHandler.Statements)
+
+	web.GET("/fundraisers", authAdminMiddleware(), fundraiserWebHandler.Index)
+	web.POST("/fundraisers/:id/status", authAdminMiddleware(), fundraiserWebHandler.UpdateStatus)
+
+	web.GET("/subscriptions", authAdminMiddleware(), subscriptionWebHandler.Index)
+
+	web.GET("/exports/transactions", authAdminMiddleware(), exportWebHandler.Transactions)
+	web.GET("/exports/campaigns", authAdminMiddleware(), exportWebHandler.Campaigns)
+	web.GET("/exports/users", authAdminMiddleware(), exportWebHandler.Users)
+
+	web.GET("/audit", authAdminMiddleware(), auditWebHandler.Index)
+	web.GET("/audit/data", authAdminMiddleware(), auditWebHandler.Data)
 
 	web.GET("/login", webAuthHandler.LoginForm)
 	web.POST("/login", webAuthHandler.LoginAction)
@@ -127,50 +317,61 @@ func main() {
 
 func authMiddleware(authService auth.Service, userService user.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
+		user, err := userFromToken(c, authService, userService)
 
-		if !strings.Contains(authHeader, "Bearer") {
+		if err != nil {
 			response := response.APIResponseFailed("Unautorized", http.StatusUnauthorized)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
 		}
 
-		tokenString := ""
+		c.Set("currentUser", user)
+	}
+}
 
-		arrayToken := strings.Split(authHeader, " ")
+// optionalAuthMiddleware sets the current user when the request carries a
+// valid token and lets guests through, for public endpoints that show the
+// owner more than everyone else.
+func optionalAuthMiddleware(authService auth.Service, userService user.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := userFromToken(c, authService, userService)
 
-		if len(arrayToken) == 2 {
-			tokenString = arrayToken[1]
+		if err == nil {
+			c.Set("currentUser", user)
 		}
+	}
+}
 
-		token, err := authService.ValidateToken(tokenString)
+func userFromToken(c *gin.Context, authService auth.Service, userService user.Service) (user.User, error) {
+	authHeader := c.GetHeader("Authorization")
 
-		if err != nil {
-			response := response.APIResponseFailed("Unautorized", http.StatusUnauthorized)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
-			return
-		}
+	if !strings.Contains(authHeader, "Bearer") {
+		return user.User{}, errors.New("MISSING BEARER TOKEN")
+	}
 
-		claim, ok := token.Claims.(jwt.MapClaims)
+	tokenString := ""
 
-		if !ok || !token.Valid {
-			response := response.APIResponseFailed("Unautorized", http.StatusUnauthorized)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
-			return
-		}
+	arrayToken := strings.Split(authHeader, " ")
 
-		userId := int(claim["user_id"].(float64))
+	if len(arrayToken) == 2 {
+		tokenString = arrayToken[1]
+	}
 
-		user, err := userService.GetUserById(userId)
+	token, err := authService.ValidateToken(tokenString)
 
-		if err != nil {
-			response := response.APIResponseFailed("Unautorized", http.StatusUnauthorized)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
-			return
-		}
+	if err != nil {
+		return user.User{}, err
+	}
 
-		c.Set("currentUser", user)
+	claim, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok || !token.Valid {
+		return user.User{}, errors.New("INVALID TOKEN")
 	}
+
+	userId := int(claim["user_id"].(float64))
+
+	return userService.GetUserById(userId)
 }
 
 func authAdminMiddleware() gin.HandlerFunc {
@@ -181,6 +382,146 @@ func authAdminMiddleware() gin.HandlerFunc {
 
 		if userIdSession == nil {
 			c.Redirect(http.StatusFound, "/web/login")
+			c.Abort()
+			return
+		}
+	}
+}
+
+// noStoreMiddleware keeps pages with personal data out of browser and proxy
+// caches, so they are gone once the admin logs out.
+func noStoreMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+	}
+}
+
+// documentURLSecret signs the time-limited document links. Without a
+// configured secret a random one is used, which only invalidates links that
+// are still open when the server restarts.
+func documentURLSecret() []byte {
+	secret := os.Getenv("DOCUMENT_URL_SECRET")
+
+	if secret != "" {
+		return []byte(secret)
+	}
+
+	randomBytes := make([]byte, 32)
+
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	return randomBytes
+}
+
+// beneficiaryNIKSecret keys the NIK hashes. Unlike the document secret it
+// cannot fall back to a random one, the hashes must stay the same across
+// restarts to recognize a household registered before.
+func beneficiaryNIKSecret() []byte {
+	secret := os.Getenv("BENEFICIARY_NIK_SECRET")
+
+	if secret == "" {
+		log.Fatal("BENEFICIARY_NIK_SECRET is not set")
+	}
+
+	return []byte(secret)
+}
+
+func purgeAuditLogs(auditService audit.Service) {
+	for {
+		auditService.Purge()
+		time.Sleep(24 * time.Hour)
+	}
+}
+
+// backfillCampaignStatus runs before the routes are served, campaigns without
+// a status would otherwise be hidden until it finishes.
+func backfillCampaignStatus(campaignService campaign.Service) {
+	approved, err := campaignService.BackfillStatus()
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	if approved > 0 {
+		log.Printf("campaign status backfill approved %d campaigns\n", approved)
+	}
+}
+
+// ensureUniqueSlugs must run before campaigns are created, two campaigns
+// created at once could otherwise get the same slug.
+func ensureUniqueSlugs(campaignService campaign.Service) {
+	renamed, err := campaignService.EnsureUniqueSlugs()
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	if renamed > 0 {
+		log.Printf("gave %d campaigns sharing a slug a new one\n", renamed)
+	}
+}
+
+// backfillLedger books donations settled before the ledger existed, without
+// it their campaigns would show nothing available to disburse.
+func backfillLedger(transactionService transaction.Service) {
+	posted, err := transactionService.BackfillLedger()
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	if posted > 0 {
+		log.Printf("ledger backfill posted %d transactions\n", posted)
+	}
+}
+
+// chargeSubscriptions runs hourly so a charge that fails is retried close to
+// its retry time.
+func chargeSubscriptions(subscriptionService subscription.Service) {
+	for {
+		err := subscriptionService.ChargeDueSubscriptions(time.Now())
+		if err != nil {
+			log.Println(err.Error())
+		}
+
+		time.Sleep(time.Hour)
+	}
+}
+
+func csrfMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+
+		token, _ := session.Get("csrfToken").(string)
+
+		if token == "" {
+			randomBytes := make([]byte, 32)
+
+			_, err := rand.Read(randomBytes)
+			if err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+
+			token = hex.EncodeToString(randomBytes)
+			session.Set("csrfToken", token)
+			session.Save()
+		}
+
+		c.Set("csrfToken", token)
+
+		if c.Request.Method != http.MethodPost {
+			return
+		}
+
+		requestToken := c.PostForm("csrf_token")
+
+		if requestToken == "" {
+			requestToken = c.GetHeader("X-CSRF-Token")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
 	}
@@ -189,20 +530,31 @@ func authAdminMiddleware() gin.HandlerFunc {
 func loadTemplates(templatesDir string) multitemplate.Renderer {
 	r := multitemplate.NewRenderer()
 
-	layouts, err := filepath.Glob(templatesDir + "/layouts/*")
+	layouts, err := filepath.Glob(templatesDir + "/layouts/*.html")
 	if err != nil {
 		panic(err.Error())
 	}
 
-	includes, err := filepath.Glob(templatesDir + "/**/*")
+	includes, err := filepath.Glob(templatesDir + "/**/*.html")
 	if err != nil {
 		panic(err.Error())
 	}
 
-	// Generate our templates map from our layouts/ and includes/ directories
+	// Generate our templates map from our layouts/ and includes/ directories.
+	// A directory with its own layouts/<directory>/ folder, like public/ and
+	// embed/, is rendered with that layout instead of the admin one.
 	for _, include := range includes {
-		layoutCopy := make([]string, len(layouts))
-		copy(layoutCopy, layouts)
+		includeLayouts, err := filepath.Glob(templatesDir + "/layouts/" + filepath.Base(filepath.Dir(include)) + "/*.html")
+		if err != nil {
+			panic(err.Error())
+		}
+
+		if len(includeLayouts) == 0 {
+			includeLayouts = layouts
+		}
+
+		layoutCopy := make([]string, len(includeLayouts))
+		copy(layoutCopy, includeLayouts)
 		files := append(layoutCopy, include)
 		r.AddFromFiles(filepath.Base(include), files...)
 	}
@@ -0,0 +1,56 @@
+package matching
+
+import (
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
+// MatchingPledge is a sponsor's promise to add Ratio rupiah for every rupiah
+// donated to a campaign between StartDate and EndDate, up to CapAmount. The
+// sponsor settles the matched amount with the platform directly, so it is
+// not part of the campaign's gateway balance.
+type MatchingPledge struct {
+	ID            int
+	CampaignID    int
+	SponsorName   string
+	Ratio         float64
+	CapAmount     int
+	MatchedAmount int
+	StartDate     time.Time
+	EndDate       time.Time
+	CreatedByID   int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsActiveAt compares calendar dates in local time, the whole of the end date
+// is included. The pledge dates are picked as plain dates, comparing them as
+// instants shifts the window whenever a date was parsed or read back in
+// another location than the donation time.
+func (p MatchingPledge) IsActiveAt(at time.Time) bool {
+	date := at.In(time.Local).Format("2006-01-02")
+
+	return date >= p.StartDate.Format("2006-01-02") && date <= p.EndDate.Format("2006-01-02")
+}
+
+func (p MatchingPledge) RemainingAmount() int {
+	return p.CapAmount - p.MatchedAmount
+}
+
+func (p MatchingPledge) FormatIDR(amount int) string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(amount)
+}
+
+type MatchingContribution struct {
+	ID            int
+	PledgeID      int
+	CampaignID    int
+	TransactionID int
+	Amount        int
+	ReversedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,13 @@
+package matching
+
+import "time"
+
+type FormCreatePledgeInput struct {
+	CampaignID  int
+	SponsorName string    `form:"sponsor_name" binding:"required"`
+	Ratio       float64   `form:"ratio" binding:"required,gt=0"`
+	CapAmount   int       `form:"cap_amount" binding:"required,gt=0"`
+	StartDate   time.Time `form:"start_date" time_format:"2006-01-02" binding:"required"`
+	EndDate     time.Time `form:"end_date" time_format:"2006-01-02" binding:"required"`
+	CreatedByID int
+}
@@ -0,0 +1,109 @@
+package matching
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	SavePledge(pledge MatchingPledge) (MatchingPledge, error)
+	FindPledgesByCampaignID(campaignId int) ([]MatchingPledge, error)
+	AddMatchedAmount(pledgeId int, amount int) (bool, error)
+	FindPledgeById(pledgeId int) (MatchingPledge, error)
+	SaveContribution(contribution MatchingContribution) (MatchingContribution, error)
+	UpdateContribution(contribution MatchingContribution) (MatchingContribution, error)
+	FindContributionsByTransactionID(transactionId int) ([]MatchingContribution, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) SavePledge(pledge MatchingPledge) (MatchingPledge, error) {
+	err := r.db.Create(&pledge).Error
+
+	if err != nil {
+		return pledge, err
+	}
+
+	return pledge, nil
+}
+
+func (r *repository) FindPledgesByCampaignID(campaignId int) ([]MatchingPledge, error) {
+	var pledges []MatchingPledge
+
+	err := r.db.Where("campaign_id = ?", campaignId).Order("start_date asc, id asc").Find(&pledges).Error
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+// AddMatchedAmount only adds when the cap still has room for the amount, so
+// two donations settled at the same time cannot push a pledge over its cap.
+// A negative amount gives back the room of a reversed contribution.
+func (r *repository) AddMatchedAmount(pledgeId int, amount int) (bool, error) {
+	result := r.db.Model(&MatchingPledge{}).
+		Where("id = ? AND matched_amount + ? <= cap_amount", pledgeId, amount).
+		Updates(map[string]interface{}{
+			"matched_amount": gorm.Expr("matched_amount + ?", amount),
+			"updated_at":     time.Now(),
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *repository) FindPledgeById(pledgeId int) (MatchingPledge, error) {
+	var pledge MatchingPledge
+
+	err := r.db.Where("id = ?", pledgeId).Find(&pledge).Error
+
+	if err != nil {
+		return pledge, err
+	}
+
+	return pledge, nil
+}
+
+func (r *repository) SaveContribution(contribution MatchingContribution) (MatchingContribution, error) {
+	err := r.db.Create(&contribution).Error
+
+	if err != nil {
+		return contribution, err
+	}
+
+	return contribution, nil
+}
+
+func (r *repository) UpdateContribution(contribution MatchingContribution) (MatchingContribution, error) {
+	err := r.db.Save(&contribution).Error
+
+	if err != nil {
+		return contribution, err
+	}
+
+	return contribution, nil
+}
+
+func (r *repository) FindContributionsByTransactionID(transactionId int) ([]MatchingContribution, error) {
+	var contributions []MatchingContribution
+
+	err := r.db.Where("transaction_id = ?", transactionId).Find(&contributions).Error
+
+	if err != nil {
+		return contributions, err
+	}
+
+	return contributions, nil
+}
@@ -0,0 +1,210 @@
+package matching
+
+import (
+	"bekasiberbagi/campaign"
+	"errors"
+	"time"
+)
+
+type Service interface {
+	CreatePledge(form FormCreatePledgeInput) (MatchingPledge, error)
+	GetPledges(campaignId int) ([]MatchingPledge, error)
+	GetMatchingTotals(campaignId int) ([]campaign.MatchingTotal, error)
+	MatchDonation(campaignId int, transactionId int, amount int, donatedAt time.Time) ([]MatchingContribution, error)
+	ReverseDonation(transactionId int) error
+}
+
+type service struct {
+	repository         Repository
+	campaignRepository campaign.Repository
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository) *service {
+	return &service{repository, campaignRepository}
+}
+
+func (s *service) CreatePledge(form FormCreatePledgeInput) (MatchingPledge, error) {
+	campaign, err := s.campaignRepository.FindById(form.CampaignID)
+
+	if err != nil {
+		return MatchingPledge{}, err
+	}
+
+	if campaign.ID == 0 {
+		return MatchingPledge{}, errors.New("CAMPAIGN NOT FOUND")
+	}
+
+	if form.EndDate.Before(form.StartDate) {
+		return MatchingPledge{}, errors.New("END DATE CANNOT BE BEFORE START DATE")
+	}
+
+	pledge := MatchingPledge{}
+	pledge.CampaignID = campaign.ID
+	pledge.SponsorName = form.SponsorName
+	pledge.Ratio = form.Ratio
+	pledge.CapAmount = form.CapAmount
+	pledge.StartDate = form.StartDate
+	pledge.EndDate = form.EndDate
+	pledge.CreatedByID = form.CreatedByID
+
+	newPledge, err := s.repository.SavePledge(pledge)
+
+	if err != nil {
+		return newPledge, err
+	}
+
+	return newPledge, nil
+}
+
+func (s *service) GetPledges(campaignId int) ([]MatchingPledge, error) {
+	pledges, err := s.repository.FindPledgesByCampaignID(campaignId)
+
+	if err != nil {
+		return pledges, err
+	}
+
+	return pledges, nil
+}
+
+func (s *service) GetMatchingTotals(campaignId int) ([]campaign.MatchingTotal, error) {
+	pledges, err := s.repository.FindPledgesByCampaignID(campaignId)
+
+	if err != nil {
+		return []campaign.MatchingTotal{}, err
+	}
+
+	totals := []campaign.MatchingTotal{}
+
+	for _, pledge := range pledges {
+		totals = append(totals, campaign.MatchingTotal{
+			SponsorName: pledge.SponsorName,
+			Ratio:       pledge.Ratio,
+			CapAmount:   pledge.CapAmount,
+			Amount:      pledge.MatchedAmount,
+			StartDate:   pledge.StartDate,
+			EndDate:     pledge.EndDate,
+		})
+	}
+
+	return totals, nil
+}
+
+// MatchDonation adds a contribution from every pledge of the campaign that
+// was running when the donation was made, trimmed to what is left of each
+// cap. A donation that was matched before is not matched again.
+func (s *service) MatchDonation(campaignId int, transactionId int, amount int, donatedAt time.Time) ([]MatchingContribution, error) {
+	contributions, err := s.repository.FindContributionsByTransactionID(transactionId)
+
+	if err != nil {
+		return contributions, err
+	}
+
+	if len(contributions) > 0 {
+		return contributions, nil
+	}
+
+	pledges, err := s.repository.FindPledgesByCampaignID(campaignId)
+
+	if err != nil {
+		return contributions, err
+	}
+
+	for _, pledge := range pledges {
+		if !pledge.IsActiveAt(donatedAt) {
+			continue
+		}
+
+		matchedAmount, err := s.reserveMatch(pledge, int(float64(amount)*pledge.Ratio))
+
+		if err != nil {
+			return contributions, err
+		}
+
+		if matchedAmount == 0 {
+			continue
+		}
+
+		contribution := MatchingContribution{}
+		contribution.PledgeID = pledge.ID
+		contribution.CampaignID = campaignId
+		contribution.TransactionID = transactionId
+		contribution.Amount = matchedAmount
+
+		newContribution, err := s.repository.SaveContribution(contribution)
+
+		if err != nil {
+			// Nothing records the reserved amount, give it back to the cap.
+			s.repository.AddMatchedAmount(pledge.ID, -matchedAmount)
+
+			return contributions, err
+		}
+
+		contributions = append(contributions, newContribution)
+	}
+
+	return contributions, nil
+}
+
+// reserveMatch takes up to amount from the cap of the pledge. When another
+// donation took part of the cap in the meantime it tries again with the
+// fresh remainder.
+func (s *service) reserveMatch(pledge MatchingPledge, amount int) (int, error) {
+	for {
+		if amount > pledge.RemainingAmount() {
+			amount = pledge.RemainingAmount()
+		}
+
+		if amount <= 0 {
+			return 0, nil
+		}
+
+		reserved, err := s.repository.AddMatchedAmount(pledge.ID, amount)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if reserved {
+			return amount, nil
+		}
+
+		pledge, err = s.repository.FindPledgeById(pledge.ID)
+
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// ReverseDonation gives the matched amount of a refunded donation back to the
+// caps of its pledges.
+func (s *service) ReverseDonation(transactionId int) error {
+	contributions, err := s.repository.FindContributionsByTransactionID(transactionId)
+
+	if err != nil {
+		return err
+	}
+
+	for _, contribution := range contributions {
+		if contribution.ReversedAt != nil {
+			continue
+		}
+
+		_, err := s.repository.AddMatchedAmount(contribution.PledgeID, -contribution.Amount)
+
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		contribution.ReversedAt = &now
+
+		_, err = s.repository.UpdateContribution(contribution)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
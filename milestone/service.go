@@ -0,0 +1,92 @@
+package milestone
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/notification"
+	"fmt"
+	"time"
+)
+
+type Service interface {
+	CheckMilestones(campaignDetail campaign.Campaign, previousAmount int) ([]campaign.CampaignMilestone, error)
+}
+
+type service struct {
+	campaignRepository  campaign.Repository
+	notificationService notification.Service
+}
+
+func NewService(campaignRepository campaign.Repository, notificationService notification.Service) *service {
+	return &service{campaignRepository, notificationService}
+}
+
+// CheckMilestones records every milestone whose threshold lies between the
+// previous and the current amount of the campaign and notifies the organizer
+// about it. A milestone is only reached once, so falling below it after a
+// refund and crossing it again does not repeat it.
+func (s *service) CheckMilestones(campaignDetail campaign.Campaign, previousAmount int) ([]campaign.CampaignMilestone, error) {
+	reached := []campaign.CampaignMilestone{}
+
+	for _, candidate := range candidates(campaignDetail) {
+		if candidate.Amount <= previousAmount || candidate.Amount > campaignDetail.CurrentAmount {
+			continue
+		}
+
+		exists, err := s.campaignRepository.MilestoneExists(campaignDetail.ID, candidate.Type, candidate.Threshold)
+
+		if err != nil {
+			return reached, err
+		}
+
+		if exists {
+			continue
+		}
+
+		candidate.ReachedAt = time.Now()
+
+		milestone, err := s.campaignRepository.SaveMilestone(candidate)
+
+		if err != nil {
+			return reached, err
+		}
+
+		s.notificationService.Notify(notification.NotifyInput{
+			UserID:  campaignDetail.UserID,
+			Title:   "Milestone reached",
+			Message: fmt.Sprintf("%s reached a milestone: %s.", campaignDetail.Name, milestone.Title),
+			Link:    campaignDetail.PublicPath(),
+		})
+
+		reached = append(reached, milestone)
+	}
+
+	return reached, nil
+}
+
+func candidates(campaignDetail campaign.Campaign) []campaign.CampaignMilestone {
+	milestones := []campaign.CampaignMilestone{}
+
+	if campaignDetail.GoalAmount > 0 {
+		for _, percentage := range campaign.MILESTONE_PERCENTAGES {
+			milestones = append(milestones, campaign.CampaignMilestone{
+				CampaignID: campaignDetail.ID,
+				Type:       campaign.MILESTONE_TYPE_PERCENTAGE,
+				Threshold:  percentage,
+				Amount:     campaignDetail.GoalAmount * percentage / 100,
+				Title:      fmt.Sprintf("%d%% of the goal", percentage),
+			})
+		}
+	}
+
+	for _, stretchGoal := range campaignDetail.StretchGoals {
+		milestones = append(milestones, campaign.CampaignMilestone{
+			CampaignID: campaignDetail.ID,
+			Type:       campaign.MILESTONE_TYPE_STRETCH_GOAL,
+			Threshold:  stretchGoal.Amount,
+			Amount:     stretchGoal.Amount,
+			Title:      stretchGoal.Title,
+		})
+	}
+
+	return milestones
+}
@@ -0,0 +1,18 @@
+package notification
+
+import "time"
+
+type Notification struct {
+	ID        int
+	UserID    int
+	Title     string
+	Message   string
+	Link      string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,15 @@
+package notification
+
+import "bekasiberbagi/user"
+
+type NotifyInput struct {
+	UserID  int
+	Title   string
+	Message string
+	Link    string
+}
+
+type GetNotificationDetailInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
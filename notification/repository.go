@@ -0,0 +1,62 @@
+package notification
+
+import "gorm.io/gorm"
+
+type Repository interface {
+	Save(notification Notification) (Notification, error)
+	Update(notification Notification) (Notification, error)
+	FindById(notificationId int) (Notification, error)
+	FindByUserID(userId int) ([]Notification, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(notification Notification) (Notification, error) {
+	err := r.db.Create(&notification).Error
+
+	if err != nil {
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (r *repository) Update(notification Notification) (Notification, error) {
+	err := r.db.Save(&notification).Error
+
+	if err != nil {
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (r *repository) FindById(notificationId int) (Notification, error) {
+	var notification Notification
+
+	err := r.db.Where("id = ?", notificationId).Find(&notification).Error
+
+	if err != nil {
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (r *repository) FindByUserID(userId int) ([]Notification, error) {
+	var notifications []Notification
+
+	err := r.db.Where("user_id = ?", userId).Order("id desc").Limit(100).Find(&notifications).Error
+
+	if err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
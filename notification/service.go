@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"errors"
+	"time"
+)
+
+type Service interface {
+	Notify(input NotifyInput) (Notification, error)
+	GetNotifications(userId int) ([]Notification, error)
+	GetUserNotification(input GetNotificationDetailInput) (Notification, error)
+	MarkAsRead(input GetNotificationDetailInput) (Notification, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) *service {
+	return &service{repository}
+}
+
+func (s *service) Notify(input NotifyInput) (Notification, error) {
+	notification := Notification{}
+	notification.UserID = input.UserID
+	notification.Title = input.Title
+	notification.Message = input.Message
+	notification.Link = input.Link
+	notification.CreatedAt = time.Now()
+	notification.UpdatedAt = time.Now()
+
+	newNotification, err := s.repository.Save(notification)
+
+	if err != nil {
+		return newNotification, err
+	}
+
+	return newNotification, nil
+}
+
+func (s *service) GetNotifications(userId int) ([]Notification, error) {
+	notifications, err := s.repository.FindByUserID(userId)
+
+	if err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
+
+func (s *service) GetUserNotification(input GetNotificationDetailInput) (Notification, error) {
+	notification, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return notification, err
+	}
+
+	if notification.ID == 0 || notification.UserID != input.User.ID {
+		return notification, errors.New("NOTIFICATION NOT FOUND")
+	}
+
+	return notification, nil
+}
+
+func (s *service) MarkAsRead(input GetNotificationDetailInput) (Notification, error) {
+	notification, err := s.GetUserNotification(input)
+
+	if err != nil {
+		return notification, err
+	}
+
+	if notification.IsRead() {
+		return notification, nil
+	}
+
+	now := time.Now()
+	notification.ReadAt = &now
+	notification.UpdatedAt = now
+
+	updatedNotification, err := s.repository.Update(notification)
+
+	if err != nil {
+		return updatedNotification, err
+	}
+
+	return updatedNotification, nil
+}
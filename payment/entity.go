@@ -1,6 +1,11 @@
 package payment
 
+// DEFAULT_PAYMENT_METHOD leaves the choice of method to the donor on the
+// Midtrans page, it is not a Midtrans payment type.
+const DEFAULT_PAYMENT_METHOD = "default"
+
 type Transaction struct {
-	ID     int
-	Amount int
+	ID            int
+	Amount        int
+	PaymentMethod string
 }
@@ -43,6 +43,11 @@ func (s *service) GetPaymentUrl(transaction Transaction, user user.User) (string
 		},
 	}
 
+	// The fee was calculated for this method, so the donor may not pick another.
+	if transaction.PaymentMethod != "" && transaction.PaymentMethod != DEFAULT_PAYMENT_METHOD {
+		snapReq.EnabledPayments = []midtrans.PaymentType{midtrans.PaymentType(transaction.PaymentMethod)}
+	}
+
 	snapTokenResp, err := snapGateway.GetToken(snapReq)
 	if err != nil {
 		return "", err
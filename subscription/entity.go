@@ -0,0 +1,65 @@
+package subscription
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
+const STATUS_ACTIVE = "active"
+const STATUS_PAUSED = "paused"
+const STATUS_CANCELLED = "cancelled"
+
+// MAX_CHARGE_ATTEMPTS counts the first charge of a cycle together with its
+// retries. When all of them fail the cycle is skipped.
+const MAX_CHARGE_ATTEMPTS = 3
+const RETRY_INTERVAL = 24 * time.Hour
+
+// PAYMENT_LINK_TTL matches the default expiry of a Midtrans Snap link. A
+// link that is never opened gets no notification, so a charge still pending
+// after this long is counted as failed.
+const PAYMENT_LINK_TTL = 24 * time.Hour
+
+type Subscription struct {
+	ID                   int
+	UserID               int
+	CampaignID           int
+	Amount               int
+	PaymentMethod        string
+	CoverFee             bool
+	DonationType         string
+	DayOfMonth           int
+	Status               string
+	NextChargeAt         time.Time
+	RetryAt              *time.Time
+	PendingTransactionID int
+	FailedAttempts       int
+	LastError            string
+	LastChargedAt        *time.Time
+	CancelledAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	User                 user.User
+	Campaign             campaign.Campaign
+}
+
+func (s Subscription) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.Amount)
+}
+
+// nextChargeAt is the first charge date on the given day of month that is
+// not before the day of from.
+func nextChargeAt(from time.Time, dayOfMonth int) time.Time {
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	chargeAt := time.Date(from.Year(), from.Month(), dayOfMonth, 0, 0, 0, 0, from.Location())
+
+	if chargeAt.Before(today) {
+		chargeAt = chargeAt.AddDate(0, 1, 0)
+	}
+
+	return chargeAt
+}
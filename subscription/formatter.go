@@ -0,0 +1,62 @@
+package subscription
+
+import "time"
+
+type SubscriptionFormatter struct {
+	ID             int                           `json:"id"`
+	CampaignID     int                           `json:"campaign_id"`
+	Amount         int                           `json:"amount"`
+	PaymentMethod  string                        `json:"payment_method"`
+	CoverFee       bool                          `json:"cover_fee"`
+	DonationType   string                        `json:"donation_type"`
+	DayOfMonth     int                           `json:"day_of_month"`
+	Status         string                        `json:"status"`
+	NextChargeAt   time.Time                     `json:"next_charge_at"`
+	RetryAt        *time.Time                    `json:"retry_at"`
+	FailedAttempts int                           `json:"failed_attempts"`
+	LastError      string                        `json:"last_error"`
+	LastChargedAt  *time.Time                    `json:"last_charged_at"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	Campaign       SubscriptionCampaignFormatter `json:"campaign"`
+}
+
+type SubscriptionCampaignFormatter struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func FormatSubscription(subscription Subscription) SubscriptionFormatter {
+	formatter := SubscriptionFormatter{}
+	formatter.ID = subscription.ID
+	formatter.CampaignID = subscription.CampaignID
+	formatter.Amount = subscription.Amount
+	formatter.PaymentMethod = subscription.PaymentMethod
+	formatter.CoverFee = subscription.CoverFee
+	formatter.DonationType = subscription.DonationType
+	formatter.DayOfMonth = subscription.DayOfMonth
+	formatter.Status = subscription.Status
+	formatter.NextChargeAt = subscription.NextChargeAt
+	formatter.RetryAt = subscription.RetryAt
+	formatter.FailedAttempts = subscription.FailedAttempts
+	formatter.LastError = subscription.LastError
+	formatter.LastChargedAt = subscription.LastChargedAt
+	formatter.CreatedAt = subscription.CreatedAt
+
+	campaignFormatter := SubscriptionCampaignFormatter{}
+	campaignFormatter.Name = subscription.Campaign.Name
+	campaignFormatter.Slug = subscription.Campaign.Slug
+
+	formatter.Campaign = campaignFormatter
+
+	return formatter
+}
+
+func FormatSubscriptions(subscriptions []Subscription) []SubscriptionFormatter {
+	subscriptionsFormatter := []SubscriptionFormatter{}
+
+	for _, subscription := range subscriptions {
+		subscriptionsFormatter = append(subscriptionsFormatter, FormatSubscription(subscription))
+	}
+
+	return subscriptionsFormatter
+}
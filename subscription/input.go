@@ -0,0 +1,19 @@
+package subscription
+
+import "bekasiberbagi/user"
+
+// DayOfMonth stops at 28 so every month has the charge date.
+type CreateSubscriptionInput struct {
+	CampaignID    int    `json:"campaign_id" binding:"required"`
+	Amount        int    `json:"amount" binding:"required,gt=0"`
+	DayOfMonth    int    `json:"day_of_month" binding:"required,min=1,max=28"`
+	PaymentMethod string `json:"payment_method"`
+	CoverFee      bool   `json:"cover_fee"`
+	DonationType  string `json:"donation_type" binding:"omitempty,oneof=zakat_maal zakat_fitrah infaq sedekah wakaf"`
+	User          user.User
+}
+
+type GetSubscriptionDetailInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
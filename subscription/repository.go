@@ -0,0 +1,94 @@
+package subscription
+
+import "gorm.io/gorm"
+
+type Repository interface {
+	Save(subscription Subscription) (Subscription, error)
+	Update(subscription Subscription) (Subscription, error)
+	FindById(subscriptionId int) (Subscription, error)
+	FindByUserID(userId int) ([]Subscription, error)
+	FindActive() ([]Subscription, error)
+	FindAll() ([]Subscription, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Save(subscription Subscription) (Subscription, error) {
+	err := r.db.Omit("Campaign", "User").Create(&subscription).Error
+
+	if err != nil {
+		return subscription, err
+	}
+
+	return subscription, nil
+}
+
+func (r *repository) Update(subscription Subscription) (Subscription, error) {
+	err := r.db.Omit("Campaign", "User").Save(&subscription).Error
+
+	if err != nil {
+		return subscription, err
+	}
+
+	return subscription, nil
+}
+
+func (r *repository) FindById(subscriptionId int) (Subscription, error) {
+	var subscription Subscription
+
+	err := r.db.Preload("Campaign").Where("id = ?", subscriptionId).Find(&subscription).Error
+
+	if err != nil {
+		return subscription, err
+	}
+
+	return subscription, nil
+}
+
+func (r *repository) FindByUserID(userId int) ([]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := r.db.Preload("Campaign").Where("user_id = ?", userId).Order("id desc").Find(&subscriptions).Error
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *repository) FindActive() ([]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := r.db.Preload("Campaign").Preload("User").Where("status = ?", STATUS_ACTIVE).Order("id asc").Find(&subscriptions).Error
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+// FindAll lists subscriptions whose last charge failed first so the admin
+// sees the donors that need attention.
+func (r *repository) FindAll() ([]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := r.db.Preload("Campaign").Preload("User").
+		Order("CASE WHEN last_error = '' THEN 1 ELSE 0 END").
+		Order("id desc").
+		Limit(200).
+		Find(&subscriptions).Error
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,322 @@
+package subscription
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/notification"
+	"bekasiberbagi/transaction"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type Service interface {
+	CreateSubscription(input CreateSubscriptionInput) (Subscription, error)
+	GetUserSubscriptions(userId int) ([]Subscription, error)
+	GetUserSubscription(input GetSubscriptionDetailInput) (Subscription, error)
+	PauseSubscription(input GetSubscriptionDetailInput) (Subscription, error)
+	ResumeSubscription(input GetSubscriptionDetailInput) (Subscription, error)
+	CancelSubscription(input GetSubscriptionDetailInput) (Subscription, error)
+	GetSubscriptions() ([]Subscription, error)
+	ChargeDueSubscriptions(now time.Time) error
+}
+
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	transactionService  transaction.Service
+	notificationService notification.Service
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, transactionService transaction.Service, notificationService notification.Service) *service {
+	return &service{repository, campaignRepository, transactionService, notificationService}
+}
+
+func (s *service) CreateSubscription(input CreateSubscriptionInput) (Subscription, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	if !campaign.IsLive() {
+		return Subscription{}, errors.New("CAMPAIGN IS NOT ACCEPTING DONATIONS")
+	}
+
+	donationType, err := campaign.ResolveDonationType(input.DonationType)
+
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	fee, err := s.transactionService.CalculateFee(transaction.GetFeeInput{
+		Amount:        input.Amount,
+		PaymentMethod: input.PaymentMethod,
+		CoverFee:      input.CoverFee,
+	})
+
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	if fee.Net <= 0 {
+		return Subscription{}, errors.New("AMOUNT IS TOO SMALL TO COVER FEES")
+	}
+
+	subscription := Subscription{}
+	subscription.UserID = input.User.ID
+	subscription.CampaignID = input.CampaignID
+	subscription.Amount = input.Amount
+	subscription.PaymentMethod = transaction.NormalizePaymentMethod(input.PaymentMethod)
+	subscription.CoverFee = input.CoverFee
+	subscription.DonationType = donationType
+	subscription.DayOfMonth = input.DayOfMonth
+	subscription.Status = STATUS_ACTIVE
+	subscription.NextChargeAt = nextChargeAt(time.Now(), input.DayOfMonth)
+
+	newSubscription, err := s.repository.Save(subscription)
+
+	if err != nil {
+		return newSubscription, err
+	}
+
+	newSubscription.Campaign = campaign
+
+	return newSubscription, nil
+}
+
+func (s *service) GetUserSubscriptions(userId int) ([]Subscription, error) {
+	subscriptions, err := s.repository.FindByUserID(userId)
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *service) GetUserSubscription(input GetSubscriptionDetailInput) (Subscription, error) {
+	subscription, err := s.repository.FindById(input.ID)
+
+	if err != nil {
+		return subscription, err
+	}
+
+	if subscription.ID == 0 || subscription.UserID != input.User.ID {
+		return subscription, errors.New("SUBSCRIPTION NOT FOUND")
+	}
+
+	return subscription, nil
+}
+
+func (s *service) PauseSubscription(input GetSubscriptionDetailInput) (Subscription, error) {
+	subscription, err := s.GetUserSubscription(input)
+
+	if err != nil {
+		return subscription, err
+	}
+
+	if subscription.Status != STATUS_ACTIVE {
+		return subscription, errors.New("SUBSCRIPTION IS NOT ACTIVE")
+	}
+
+	subscription.Status = STATUS_PAUSED
+	subscription.RetryAt = nil
+	subscription.FailedAttempts = 0
+
+	updatedSubscription, err := s.repository.Update(subscription)
+
+	if err != nil {
+		return updatedSubscription, err
+	}
+
+	return updatedSubscription, nil
+}
+
+// ResumeSubscription does not charge the cycles that passed while the
+// subscription was paused, it continues with the next charge date.
+func (s *service) ResumeSubscription(input GetSubscriptionDetailInput) (Subscription, error) {
+	subscription, err := s.GetUserSubscription(input)
+
+	if err != nil {
+		return subscription, err
+	}
+
+	if subscription.Status != STATUS_PAUSED {
+		return subscription, errors.New("SUBSCRIPTION IS NOT PAUSED")
+	}
+
+	now := time.Now()
+
+	subscription.Status = STATUS_ACTIVE
+
+	if subscription.NextChargeAt.Before(now) {
+		subscription.NextChargeAt = nextChargeAt(now, subscription.DayOfMonth)
+	}
+
+	updatedSubscription, err := s.repository.Update(subscription)
+
+	if err != nil {
+		return updatedSubscription, err
+	}
+
+	return updatedSubscription, nil
+}
+
+func (s *service) CancelSubscription(input GetSubscriptionDetailInput) (Subscription, error) {
+	subscription, err := s.GetUserSubscription(input)
+
+	if err != nil {
+		return subscription, err
+	}
+
+	if subscription.Status == STATUS_CANCELLED {
+		return subscription, errors.New("SUBSCRIPTION IS ALREADY CANCELLED")
+	}
+
+	now := time.Now()
+
+	subscription.Status = STATUS_CANCELLED
+	subscription.RetryAt = nil
+	subscription.CancelledAt = &now
+
+	updatedSubscription, err := s.repository.Update(subscription)
+
+	if err != nil {
+		return updatedSubscription, err
+	}
+
+	return updatedSubscription, nil
+}
+
+func (s *service) GetSubscriptions() ([]Subscription, error) {
+	subscriptions, err := s.repository.FindAll()
+
+	if err != nil {
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+// ChargeDueSubscriptions first settles the charge each subscription is
+// waiting on, then creates a payment for every subscription whose cycle or
+// retry is due. A failing subscription is logged and does not stop the rest.
+func (s *service) ChargeDueSubscriptions(now time.Time) error {
+	subscriptions, err := s.repository.FindActive()
+
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		err := s.chargeSubscription(subscription, now)
+
+		if err != nil {
+			log.Printf("subscription %d: %s", subscription.ID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *service) chargeSubscription(subscription Subscription, now time.Time) error {
+	if subscription.PendingTransactionID != 0 {
+		pendingTransaction, err := s.transactionService.GetTransactionById(subscription.PendingTransactionID)
+
+		if err != nil {
+			return err
+		}
+
+		if pendingTransaction.Status == "pending" && now.Sub(pendingTransaction.CreatedAt) < PAYMENT_LINK_TTL {
+			return nil
+		}
+
+		subscription.PendingTransactionID = 0
+
+		if pendingTransaction.Status == "paid" || pendingTransaction.Status == "refunded" {
+			subscription.FailedAttempts = 0
+			subscription.RetryAt = nil
+			subscription.LastError = ""
+			subscription.LastChargedAt = &pendingTransaction.UpdatedAt
+		} else {
+			s.recordFailure(&subscription, now, "payment "+pendingTransaction.Status)
+		}
+	}
+
+	cycleDue := !subscription.NextChargeAt.After(now)
+	retryDue := subscription.RetryAt != nil && !subscription.RetryAt.After(now)
+
+	if subscription.PendingTransactionID == 0 && (cycleDue || retryDue) {
+		// A new cycle replaces the retries of the previous one.
+		if cycleDue {
+			for !subscription.NextChargeAt.After(now) {
+				subscription.NextChargeAt = subscription.NextChargeAt.AddDate(0, 1, 0)
+			}
+
+			subscription.FailedAttempts = 0
+		}
+
+		subscription.RetryAt = nil
+
+		newTransaction, err := s.transactionService.CreateTransaction(transaction.CreateTransactionInput{
+			Amount:         subscription.Amount,
+			CampaignId:     subscription.CampaignID,
+			PaymentMethod:  subscription.PaymentMethod,
+			CoverFee:       subscription.CoverFee,
+			DonationType:   subscription.DonationType,
+			SubscriptionID: subscription.ID,
+			User:           subscription.User,
+		})
+
+		if err != nil {
+			s.recordFailure(&subscription, now, err.Error())
+		} else {
+			subscription.PendingTransactionID = newTransaction.ID
+
+			s.notificationService.Notify(notification.NotifyInput{
+				UserID:  subscription.UserID,
+				Title:   "Monthly donation is ready",
+				Message: fmt.Sprintf("Your monthly donation of %s to %s is ready to be paid.", subscription.AmountFormatIDR(), subscription.Campaign.Name),
+				Link:    newTransaction.PaymentUrl,
+			})
+		}
+	}
+
+	_, err := s.repository.Update(subscription)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// recordFailure schedules a retry of the current cycle, or gives the cycle
+// up once MAX_CHARGE_ATTEMPTS is reached, and tells the donor either way.
+func (s *service) recordFailure(subscription *Subscription, now time.Time, reason string) {
+	subscription.FailedAttempts = subscription.FailedAttempts + 1
+	subscription.LastError = reason
+
+	if subscription.FailedAttempts < MAX_CHARGE_ATTEMPTS {
+		retryAt := now.Add(RETRY_INTERVAL)
+		subscription.RetryAt = &retryAt
+
+		s.notificationService.Notify(notification.NotifyInput{
+			UserID:  subscription.UserID,
+			Title:   "Monthly donation failed",
+			Message: fmt.Sprintf("Your monthly donation of %s to %s could not be charged, we will try again tomorrow.", subscription.AmountFormatIDR(), subscription.Campaign.Name),
+		})
+
+		return
+	}
+
+	subscription.FailedAttempts = 0
+	subscription.RetryAt = nil
+
+	s.notificationService.Notify(notification.NotifyInput{
+		UserID:  subscription.UserID,
+		Title:   "Monthly donation skipped",
+		Message: fmt.Sprintf("Your monthly donation of %s to %s failed %d times and is skipped this month.", subscription.AmountFormatIDR(), subscription.Campaign.Name, MAX_CHARGE_ATTEMPTS),
+	})
+}
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"bekasiberbagi/campaign"
+	"bekasiberbagi/ledger"
 	"bekasiberbagi/user"
 	"time"
 
@@ -9,17 +10,27 @@ import (
 )
 
 type Transaction struct {
-	ID         int
-	CampaignID int
-	UserID     int
-	Amount     int
-	Status     string
-	Code       string
-	PaymentUrl string
-	User       user.User
-	Campaign   campaign.Campaign
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID             int
+	CampaignID     int
+	UserID         int
+	Amount         int
+	PaymentMethod  string
+	CoverFee       bool
+	Tip            int
+	GatewayFee     int
+	PlatformFee    int
+	GrossAmount    int
+	NetAmount      int
+	SubscriptionID int
+	FundraiserID   int
+	DonationType   string
+	Status         string
+	Code           string
+	PaymentUrl     string
+	User           user.User
+	Campaign       campaign.Campaign
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (t Transaction) AmountFormatIDR() string {
@@ -27,3 +38,114 @@ func (t Transaction) AmountFormatIDR() string {
 
 	return ac.FormatMoney(t.Amount)
 }
+
+func (t Transaction) FeeAmount() int {
+	return t.GatewayFee + t.PlatformFee
+}
+
+// PaidAt is when the payment settled. A paid transaction is not updated after
+// its status changed to paid, so its last update is the settlement time.
+func (t Transaction) PaidAt() time.Time {
+	return t.UpdatedAt
+}
+
+// TipGatewayFee is the part of the gateway fee paid from the tip. It is
+// worked out from the stored amounts, transactions from before the tip paid
+// its own fee come out as 0.
+func (t Transaction) TipGatewayFee() int {
+	if t.GrossAmount == 0 {
+		return 0
+	}
+
+	return t.NetAmount + t.GatewayFee + t.PlatformFee + t.Tip - t.GrossAmount
+}
+
+// ChargedAmount is what the donor paid. Transactions from before fees were
+// modeled have no gross amount and charged exactly the donation.
+func (t Transaction) ChargedAmount() int {
+	if t.GrossAmount == 0 {
+		return t.Amount
+	}
+
+	return t.GrossAmount
+}
+
+// CampaignAmount is what the campaign receives after fees.
+func (t Transaction) CampaignAmount() int {
+	if t.GrossAmount == 0 {
+		return t.Amount
+	}
+
+	return t.NetAmount
+}
+
+// DonatedAmount is what the donor paid for the campaign, including the fees
+// they chose to cover but not the tip to the platform. Donor statements
+// report this amount.
+func (t Transaction) DonatedAmount() int {
+	return t.ChargedAmount() - t.Tip
+}
+
+// EffectiveDonationType counts transactions from before donation types were
+// recorded as sedekah.
+func (t Transaction) EffectiveDonationType() string {
+	if t.DonationType == "" {
+		return campaign.DONATION_TYPE_SEDEKAH
+	}
+
+	return t.DonationType
+}
+
+func (t Transaction) DonationTypeLabel() string {
+	return campaign.DonationTypeLabel(t.EffectiveDonationType())
+}
+
+func (t Transaction) FormatIDR(amount int) string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(amount)
+}
+
+// PaymentChange is what a new transaction status does to the campaign and
+// fundraiser counters and to the ledger. PreviousStatus guards against
+// applying the same change twice.
+type PaymentChange struct {
+	PreviousStatus string
+	AmountDelta    int
+	BackerDelta    int
+	Journals       []ledger.LedgerJournal
+}
+
+type StatusSummary struct {
+	Status string
+	Count  int
+	Amount int
+}
+
+func (s StatusSummary) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.Amount)
+}
+
+type DonationTypeSummary struct {
+	DonationType string
+	Count        int
+	Amount       int
+}
+
+func (s DonationTypeSummary) Label() string {
+	return campaign.DonationTypeLabel(s.DonationType)
+}
+
+func (s DonationTypeSummary) AmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.Amount)
+}
+
+type DailyTotal struct {
+	Date   time.Time
+	Count  int
+	Amount int
+}
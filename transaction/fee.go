@@ -0,0 +1,157 @@
+package transaction
+
+import (
+	"bekasiberbagi/payment"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const DEFAULT_PAYMENT_METHOD = payment.DEFAULT_PAYMENT_METHOD
+
+type GatewayFee struct {
+	Percent float64
+	Fixed   int
+}
+
+func (f GatewayFee) Of(gross int) int {
+	return int(math.Round(float64(gross)*f.Percent/100)) + f.Fixed
+}
+
+// FeeSchedule holds the gateway fee per Midtrans payment method and the
+// platform fee taken from every donation.
+type FeeSchedule struct {
+	GatewayFees     map[string]GatewayFee
+	PlatformPercent float64
+}
+
+// TipGatewayFee is the part of GatewayFee charged on the tip, it is taken
+// from the tip rather than from the campaign.
+type FeeBreakdown struct {
+	Amount        int
+	Tip           int
+	GatewayFee    int
+	TipGatewayFee int
+	PlatformFee   int
+	Gross         int
+	Net           int
+}
+
+// ParseFeeSchedule reads gateway fees written as comma separated
+// method:fee pairs, where a fee is a fixed rupiah amount, a percentage or
+// both, for example "default:4000,gopay:2%,credit_card:2.9%+2000".
+func ParseFeeSchedule(gatewayFees string, platformPercent string) (FeeSchedule, error) {
+	schedule := FeeSchedule{GatewayFees: map[string]GatewayFee{DEFAULT_PAYMENT_METHOD: {}}}
+
+	for _, pair := range strings.Split(gatewayFees, ",") {
+		pair = strings.TrimSpace(pair)
+
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, ":", 2)
+
+		if len(parts) != 2 {
+			return schedule, errors.New("INVALID GATEWAY FEE " + pair)
+		}
+
+		fee := GatewayFee{}
+
+		for _, component := range strings.Split(parts[1], "+") {
+			component = strings.TrimSpace(component)
+
+			if strings.HasSuffix(component, "%") {
+				percent, err := strconv.ParseFloat(strings.TrimSuffix(component, "%"), 64)
+				if err != nil || !isValidPercent(percent) {
+					return schedule, errors.New("INVALID GATEWAY FEE " + pair)
+				}
+
+				fee.Percent = percent
+			} else {
+				fixed, err := strconv.Atoi(component)
+				if err != nil || fixed < 0 {
+					return schedule, errors.New("INVALID GATEWAY FEE " + pair)
+				}
+
+				fee.Fixed = fixed
+			}
+		}
+
+		schedule.GatewayFees[strings.TrimSpace(parts[0])] = fee
+	}
+
+	if platformPercent != "" {
+		percent, err := strconv.ParseFloat(platformPercent, 64)
+		if err != nil || !isValidPercent(percent) {
+			return schedule, errors.New("INVALID PLATFORM FEE PERCENT")
+		}
+
+		schedule.PlatformPercent = percent
+	}
+
+	return schedule, nil
+}
+
+// isValidPercent rejects fees of 100% or more, a donor covering such a fee
+// could never pay enough for the campaign to receive anything.
+func isValidPercent(percent float64) bool {
+	return percent >= 0 && percent < 100
+}
+
+// NormalizePaymentMethod stores the default method as no method, both leave
+// the choice to the donor.
+func NormalizePaymentMethod(method string) string {
+	if method == DEFAULT_PAYMENT_METHOD {
+		return ""
+	}
+
+	return method
+}
+
+func (s FeeSchedule) Supports(method string) bool {
+	if method == "" {
+		return true
+	}
+
+	_, ok := s.GatewayFees[method]
+
+	return ok
+}
+
+// Calculate splits a donation into what the donor pays and what the campaign
+// keeps. The tip always goes to the platform on top of the donation. When the
+// donor covers the fee the gross amount is raised until the campaign nets the
+// full donation, otherwise the gateway fee is split between the donation and
+// the tip by their share of the gross amount and each pays its own part.
+func (s FeeSchedule) Calculate(amount int, tip int, method string, coverFee bool) FeeBreakdown {
+	if method == "" {
+		method = DEFAULT_PAYMENT_METHOD
+	}
+
+	gatewayFee := s.GatewayFees[method]
+
+	breakdown := FeeBreakdown{Amount: amount, Tip: tip}
+	breakdown.PlatformFee = int(math.Round(float64(amount) * s.PlatformPercent / 100))
+
+	if coverFee {
+		keep := amount + tip + breakdown.PlatformFee
+		breakdown.Gross = int(math.Ceil(float64(keep+gatewayFee.Fixed) / (1 - gatewayFee.Percent/100)))
+		breakdown.GatewayFee = breakdown.Gross - keep
+		breakdown.Net = amount
+
+		return breakdown
+	}
+
+	breakdown.Gross = amount + tip
+	breakdown.GatewayFee = gatewayFee.Of(breakdown.Gross)
+
+	if breakdown.Gross > 0 {
+		breakdown.TipGatewayFee = int(math.Round(float64(breakdown.GatewayFee) * float64(tip) / float64(breakdown.Gross)))
+	}
+
+	breakdown.Net = amount - (breakdown.GatewayFee - breakdown.TipGatewayFee) - breakdown.PlatformFee
+
+	return breakdown
+}
@@ -0,0 +1,171 @@
+package transaction
+
+import "testing"
+
+func TestParseFeeSchedule(t *testing.T) {
+	schedule, err := ParseFeeSchedule("default:4000, gopay:2%, credit_card:2.9%+2000", "5")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]GatewayFee{
+		DEFAULT_PAYMENT_METHOD: {Fixed: 4000},
+		"gopay":                {Percent: 2},
+		"credit_card":          {Percent: 2.9, Fixed: 2000},
+	}
+
+	for method, fee := range expected {
+		if schedule.GatewayFees[method] != fee {
+			t.Errorf("%s: expected %+v, got %+v", method, fee, schedule.GatewayFees[method])
+		}
+	}
+
+	if schedule.PlatformPercent != 5 {
+		t.Errorf("expected a platform fee of 5%%, got %v%%", schedule.PlatformPercent)
+	}
+}
+
+func TestParseFeeScheduleRejectsInvalidFees(t *testing.T) {
+	tests := []struct {
+		name            string
+		gatewayFees     string
+		platformPercent string
+	}{
+		{name: "missing fee", gatewayFees: "gopay"},
+		{name: "not a number", gatewayFees: "gopay:two%"},
+		{name: "gateway percent of 100", gatewayFees: "gopay:100%"},
+		{name: "gateway percent above 100", gatewayFees: "gopay:150%"},
+		{name: "negative gateway percent", gatewayFees: "gopay:-1%"},
+		{name: "negative fixed fee", gatewayFees: "default:-4000"},
+		{name: "platform percent of 100", platformPercent: "100"},
+		{name: "negative platform percent", platformPercent: "-5"},
+		{name: "platform percent not a number", platformPercent: "NaN"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseFeeSchedule(test.gatewayFees, test.platformPercent)
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	schedule := FeeSchedule{
+		GatewayFees: map[string]GatewayFee{
+			DEFAULT_PAYMENT_METHOD: {Fixed: 4000},
+			"gopay":                {Percent: 2},
+			"credit_card":          {Percent: 2.9, Fixed: 2000},
+		},
+	}
+
+	withPlatformFee := schedule
+	withPlatformFee.PlatformPercent = 5
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int
+		tip      int
+		method   string
+		coverFee bool
+		expected FeeBreakdown
+	}{
+		{
+			name:     "fixed fee taken from the donation",
+			schedule: schedule,
+			amount:   100000,
+			expected: FeeBreakdown{Amount: 100000, GatewayFee: 4000, Gross: 100000, Net: 96000},
+		},
+		{
+			name:     "fixed fee covered by the donor",
+			schedule: schedule,
+			amount:   100000,
+			coverFee: true,
+			expected: FeeBreakdown{Amount: 100000, GatewayFee: 4000, Gross: 104000, Net: 100000},
+		},
+		{
+			name:     "percentage fee on the donation and the tip",
+			schedule: schedule,
+			amount:   100000,
+			tip:      5000,
+			method:   "gopay",
+			expected: FeeBreakdown{Amount: 100000, Tip: 5000, GatewayFee: 2100, TipGatewayFee: 100, Gross: 105000, Net: 98000},
+		},
+		{
+			name:     "fixed fee split between the donation and the tip",
+			schedule: schedule,
+			amount:   100000,
+			tip:      5000,
+			expected: FeeBreakdown{Amount: 100000, Tip: 5000, GatewayFee: 4000, TipGatewayFee: 190, Gross: 105000, Net: 96190},
+		},
+		{
+			name:     "percentage fee covered by the donor with a tip",
+			schedule: schedule,
+			amount:   100000,
+			tip:      5000,
+			method:   "gopay",
+			coverFee: true,
+			expected: FeeBreakdown{Amount: 100000, Tip: 5000, GatewayFee: 2143, Gross: 107143, Net: 100000},
+		},
+		{
+			name:     "percentage and fixed fee with a platform fee",
+			schedule: withPlatformFee,
+			amount:   100000,
+			method:   "credit_card",
+			expected: FeeBreakdown{Amount: 100000, GatewayFee: 4900, PlatformFee: 5000, Gross: 100000, Net: 90100},
+		},
+		{
+			name:     "percentage and fixed fee with a platform fee covered by the donor",
+			schedule: withPlatformFee,
+			amount:   100000,
+			method:   "credit_card",
+			coverFee: true,
+			expected: FeeBreakdown{Amount: 100000, GatewayFee: 5196, PlatformFee: 5000, Gross: 110196, Net: 100000},
+		},
+		{
+			name:     "no fees configured",
+			schedule: FeeSchedule{GatewayFees: map[string]GatewayFee{DEFAULT_PAYMENT_METHOD: {}}},
+			amount:   50000,
+			tip:      2000,
+			coverFee: true,
+			expected: FeeBreakdown{Amount: 50000, Tip: 2000, Gross: 52000, Net: 50000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			breakdown := test.schedule.Calculate(test.amount, test.tip, test.method, test.coverFee)
+
+			if breakdown != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, breakdown)
+			}
+
+			// Whatever the donor pays is split between the campaign, the
+			// gateway, the platform fee and the tip after its gateway fee.
+			if breakdown.Gross != breakdown.Net+breakdown.GatewayFee+breakdown.PlatformFee+breakdown.Tip-breakdown.TipGatewayFee {
+				t.Fatalf("gross %d does not add up for %+v", breakdown.Gross, breakdown)
+			}
+
+			// A covered fee must pay at least what the gateway charges on the
+			// gross amount.
+			if test.coverFee {
+				method := test.method
+
+				if method == "" {
+					method = DEFAULT_PAYMENT_METHOD
+				}
+
+				charged := test.schedule.GatewayFees[method].Of(breakdown.Gross)
+
+				if breakdown.GatewayFee < charged {
+					t.Fatalf("covered gateway fee %d is less than the %d charged", breakdown.GatewayFee, charged)
+				}
+			}
+		})
+	}
+}
@@ -10,11 +10,12 @@ type CampaignTransactionFormatter struct {
 }
 
 type UserTransactionFormatter struct {
-	ID        int                              `json:"id"`
-	Amount    int                              `json:"amount"`
-	Status    string                           `json:"status"`
-	CreatedAt time.Time                        `json:"created_at"`
-	Campaign  UserTransactionCampaignFormatter `json:"campaign"`
+	ID           int                              `json:"id"`
+	Amount       int                              `json:"amount"`
+	DonationType string                           `json:"donation_type"`
+	Status       string                           `json:"status"`
+	CreatedAt    time.Time                        `json:"created_at"`
+	Campaign     UserTransactionCampaignFormatter `json:"campaign"`
 }
 
 type UserTransactionCampaignFormatter struct {
@@ -23,13 +24,20 @@ type UserTransactionCampaignFormatter struct {
 }
 
 type TransactionFormatter struct {
-	ID         int    `json:"id"`
-	CampaignID int    `json:"campaign_id"`
-	UserID     int    `json:"user_id"`
-	Amount     int    `json:"amount"`
-	Status     string `json:"status"`
-	Code       string `json:"code"`
-	PaymentUrl string `json:"payment_url"`
+	ID            int    `json:"id"`
+	CampaignID    int    `json:"campaign_id"`
+	UserID        int    `json:"user_id"`
+	Amount        int    `json:"amount"`
+	PaymentMethod string `json:"payment_method"`
+	Tip           int    `json:"tip"`
+	FeeAmount     int    `json:"fee_amount"`
+	GrossAmount   int    `json:"gross_amount"`
+	NetAmount     int    `json:"net_amount"`
+	FundraiserID  int    `json:"fundraiser_id"`
+	DonationType  string `json:"donation_type"`
+	Status        string `json:"status"`
+	Code          string `json:"code"`
+	PaymentUrl    string `json:"payment_url"`
 }
 
 func FormatCampaignTransaction(transaction Transaction) CampaignTransactionFormatter {
@@ -61,6 +69,7 @@ func FormatUserTransaction(transaction Transaction) UserTransactionFormatter {
 	formatter := UserTransactionFormatter{}
 	formatter.ID = transaction.ID
 	formatter.Amount = transaction.Amount
+	formatter.DonationType = transaction.EffectiveDonationType()
 	formatter.Status = transaction.Status
 	formatter.CreatedAt = transaction.CreatedAt
 
@@ -98,9 +107,118 @@ func FormatTransaction(transaction Transaction) TransactionFormatter {
 	formatter.CampaignID = transaction.Campaign.ID
 	formatter.UserID = transaction.User.ID
 	formatter.Amount = transaction.Amount
+	formatter.PaymentMethod = transaction.PaymentMethod
+	formatter.Tip = transaction.Tip
+	formatter.FeeAmount = transaction.FeeAmount()
+	formatter.GrossAmount = transaction.ChargedAmount()
+	formatter.NetAmount = transaction.CampaignAmount()
+	formatter.FundraiserID = transaction.FundraiserID
+	formatter.DonationType = transaction.EffectiveDonationType()
 	formatter.Status = transaction.Status
 	formatter.Code = transaction.Code
 	formatter.PaymentUrl = transaction.PaymentUrl
 
 	return formatter
 }
+
+type FeeFormatter struct {
+	Amount        int `json:"amount"`
+	Tip           int `json:"tip"`
+	GatewayFee    int `json:"gateway_fee"`
+	TipGatewayFee int `json:"tip_gateway_fee"`
+	PlatformFee   int `json:"platform_fee"`
+	GrossAmount   int `json:"gross_amount"`
+	NetAmount     int `json:"net_amount"`
+}
+
+func FormatFee(fee FeeBreakdown) FeeFormatter {
+	formatter := FeeFormatter{}
+	formatter.Amount = fee.Amount
+	formatter.Tip = fee.Tip
+	formatter.GatewayFee = fee.GatewayFee
+	formatter.TipGatewayFee = fee.TipGatewayFee
+	formatter.PlatformFee = fee.PlatformFee
+	formatter.GrossAmount = fee.Gross
+	formatter.NetAmount = fee.Net
+
+	return formatter
+}
+
+type StatementFormatter struct {
+	Year          int                              `json:"year"`
+	UserID        int                              `json:"user_id"`
+	Name          string                           `json:"name"`
+	Email         string                           `json:"email"`
+	TotalAmount   int                              `json:"total_amount"`
+	DonationTypes []StatementDonationTypeFormatter `json:"donation_types"`
+	Campaigns     []StatementCampaignFormatter     `json:"campaigns"`
+}
+
+type StatementDonationTypeFormatter struct {
+	DonationType string `json:"donation_type"`
+	TotalAmount  int    `json:"total_amount"`
+}
+
+type StatementCampaignFormatter struct {
+	CampaignID   int                             `json:"campaign_id"`
+	CampaignName string                          `json:"campaign_name"`
+	TotalAmount  int                             `json:"total_amount"`
+	Transactions []StatementTransactionFormatter `json:"transactions"`
+}
+
+type StatementTransactionFormatter struct {
+	ID           int       `json:"id"`
+	Amount       int       `json:"amount"`
+	PaidAmount   int       `json:"paid_amount"`
+	DonationType string    `json:"donation_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	PaidAt       time.Time `json:"paid_at"`
+}
+
+func FormatStatement(statement Statement) StatementFormatter {
+	formatter := StatementFormatter{}
+	formatter.Year = statement.Year
+	formatter.UserID = statement.User.ID
+	formatter.Name = statement.User.Name
+	formatter.Email = statement.User.Email
+	formatter.TotalAmount = statement.TotalAmount
+	formatter.DonationTypes = []StatementDonationTypeFormatter{}
+
+	for _, statementType := range statement.DonationTypes {
+		formatter.DonationTypes = append(formatter.DonationTypes, StatementDonationTypeFormatter{
+			DonationType: statementType.DonationType,
+			TotalAmount:  statementType.TotalAmount,
+		})
+	}
+
+	campaignsFormatter := []StatementCampaignFormatter{}
+
+	for _, statementCampaign := range statement.Campaigns {
+		campaignFormatter := StatementCampaignFormatter{}
+		campaignFormatter.CampaignID = statementCampaign.CampaignID
+		campaignFormatter.CampaignName = statementCampaign.CampaignName
+		campaignFormatter.TotalAmount = statementCampaign.TotalAmount
+
+		transactionsFormatter := []StatementTransactionFormatter{}
+
+		for _, transaction := range statementCampaign.Transactions {
+			transactionFormatter := StatementTransactionFormatter{}
+			transactionFormatter.ID = transaction.ID
+			transactionFormatter.Amount = transaction.Amount
+			transactionFormatter.PaidAmount = transaction.DonatedAmount()
+			transactionFormatter.DonationType = transaction.EffectiveDonationType()
+			transactionFormatter.CreatedAt = transaction.CreatedAt
+			transactionFormatter.PaidAt = transaction.PaidAt()
+
+			transactionsFormatter = append(transactionsFormatter, transactionFormatter)
+		}
+
+		campaignFormatter.Transactions = transactionsFormatter
+
+		campaignsFormatter = append(campaignsFormatter, campaignFormatter)
+	}
+
+	formatter.Campaigns = campaignsFormatter
+
+	return formatter
+}
@@ -1,6 +1,9 @@
 package transaction
 
-import "bekasiberbagi/user"
+import (
+	"bekasiberbagi/user"
+	"time"
+)
 
 type GetCampaignTransactionInput struct {
 	ID   int `uri:"id" binding:"required"`
@@ -8,9 +11,22 @@ type GetCampaignTransactionInput struct {
 }
 
 type CreateTransactionInput struct {
-	Amount     int `json:"amount" binding:"required"`
-	CampaignId int `json:"campaign_id" binding:"required"`
-	User       user.User
+	Amount         int    `json:"amount" binding:"required"`
+	CampaignId     int    `json:"campaign_id" binding:"required"`
+	PaymentMethod  string `json:"payment_method"`
+	Tip            int    `json:"tip" binding:"gte=0"`
+	CoverFee       bool   `json:"cover_fee"`
+	FundraiserID   int    `json:"fundraiser_id"`
+	DonationType   string `json:"donation_type" binding:"omitempty,oneof=zakat_maal zakat_fitrah infaq sedekah wakaf"`
+	SubscriptionID int    `json:"-"`
+	User           user.User
+}
+
+type GetFeeInput struct {
+	Amount        int    `form:"amount" binding:"required,gt=0"`
+	PaymentMethod string `form:"payment_method"`
+	Tip           int    `form:"tip" binding:"gte=0"`
+	CoverFee      bool   `form:"cover_fee"`
 }
 
 type TransactionNotificationInput struct {
@@ -19,3 +35,16 @@ type TransactionNotificationInput struct {
 	PaymentType       string `json:"payment_type"`
 	FraudStatus       string `json:"fraud_status"`
 }
+
+type GetStatementInput struct {
+	Year int `uri:"year" binding:"required"`
+	User user.User
+}
+
+type ExportTransactionInput struct {
+	StartDate  time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate    time.Time `form:"end_date" time_format:"2006-01-02"`
+	CampaignID int       `form:"campaign_id"`
+	Status     string    `form:"status"`
+	Format     string    `form:"format"`
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+func GenerateStatementPdf(statement Statement) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Laporan Donasi %d", statement.Year), true)
+
+	// The core fonts are not UTF-8, names are translated to their code page
+	// so letters such as é print as such.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "BEKASIBERBAGI", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(0, 8, fmt.Sprintf("Laporan Donasi Tahun %d", statement.Year), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("Nama  : %s", statement.User.Name)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("Email : %s", statement.User.Email)), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	for _, statementCampaign := range statement.Campaigns {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 8, tr(statementCampaign.CampaignName), "B", 1, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 10)
+		for _, transaction := range statementCampaign.Transactions {
+			pdf.CellFormat(30, 6, fmt.Sprintf("#%d", transaction.ID), "", 0, "L", false, 0, "")
+			pdf.CellFormat(45, 6, transaction.PaidAt().Format("02 Jan 2006 15:04"), "", 0, "L", false, 0, "")
+			pdf.CellFormat(35, 6, tr(transaction.DonationTypeLabel()), "", 0, "L", false, 0, "")
+			pdf.CellFormat(0, 6, transaction.FormatIDR(transaction.DonatedAmount()), "", 1, "R", false, 0, "")
+		}
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(110, 6, "Subtotal", "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, statementCampaign.TotalAmountFormatIDR(), "", 1, "R", false, 0, "")
+		pdf.Ln(2)
+	}
+
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 8, "Ringkasan per Jenis Donasi", "B", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, statementType := range statement.DonationTypes {
+		pdf.CellFormat(110, 6, tr(statementType.Label()), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, statementType.TotalAmountFormatIDR(), "", 1, "R", false, 0, "")
+	}
+
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(110, 8, "Total Donasi", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 8, statement.TotalAmountFormatIDR(), "T", 1, "R", false, 0, "")
+
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.MultiCell(0, 4, "Jumlah adalah yang dibayarkan donatur untuk kampanye, termasuk biaya transaksi yang ditanggung donatur dan tidak termasuk tip untuk platform.", "", "L", false)
+
+	var buffer bytes.Buffer
+
+	err := pdf.Output(&buffer)
+
+	if err != nil {
+		return buffer.Bytes(), err
+	}
+
+	return buffer.Bytes(), nil
+}
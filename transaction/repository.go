@@ -1,6 +1,14 @@
 package transaction
 
-import "gorm.io/gorm"
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/datatable"
+	"bekasiberbagi/fundraiser"
+	"bekasiberbagi/ledger"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type repository struct {
 	db *gorm.DB
@@ -11,8 +19,19 @@ type Repository interface {
 	GetByUserId(userId int) ([]Transaction, error)
 	SaveTransaction(transaction Transaction) (Transaction, error)
 	Update(transaction Transaction) (Transaction, error)
+	UpdateStatus(transaction Transaction, change PaymentChange) (bool, error)
 	GetById(transactionId int) (Transaction, error)
 	GetAll() ([]Transaction, error)
+	GetPaidByYear(year int) ([]Transaction, error)
+	FindWithoutDonationJournal(afterId int, limit int) ([]Transaction, error)
+	FindInBatches(input ExportTransactionInput, batchSize int, fn func(transactions []Transaction) error) error
+	SumPaidSince(since time.Time) (int, error)
+	SummarizeByStatus() ([]StatusSummary, error)
+	SummarizePaidByDonationType() ([]DonationTypeSummary, error)
+	DailyPaidTotals(since time.Time) ([]DailyTotal, error)
+	FindPaginated(request datatable.Request) ([]Transaction, int64, int64, error)
+	GetPaidByCampaignId(campaignId int, limit int) ([]Transaction, error)
+	GetPaidByFundraiserId(fundraiserId int, limit int) ([]Transaction, error)
 }
 
 func NewRepository(db *gorm.DB) *repository {
@@ -63,6 +82,71 @@ func (r *repository) Update(transaction Transaction) (Transaction, error) {
 	return transaction, nil
 }
 
+// UpdateStatus saves the new status of a transaction together with the
+// counters and journals it moves. It reports false without writing anything
+// when the stored status is no longer change.PreviousStatus, which happens
+// when the same notification is delivered twice at once.
+func (r *repository) UpdateStatus(transaction Transaction, change PaymentChange) (bool, error) {
+	applied := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Transaction{}).Where("id = ? AND status = ?", transaction.ID, change.PreviousStatus).Updates(map[string]interface{}{
+			"status":     transaction.Status,
+			"updated_at": time.Now(),
+		})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		applied = true
+
+		if change.AmountDelta != 0 || change.BackerDelta != 0 {
+			err := tx.Model(&campaign.Campaign{}).Where("id = ?", transaction.CampaignID).Updates(map[string]interface{}{
+				"current_amount": gorm.Expr("current_amount + ?", change.AmountDelta),
+				"backer_count":   gorm.Expr("backer_count + ?", change.BackerDelta),
+				"updated_at":     time.Now(),
+			}).Error
+
+			if err != nil {
+				return err
+			}
+
+			if transaction.FundraiserID != 0 {
+				err = tx.Model(&fundraiser.Fundraiser{}).Where("id = ?", transaction.FundraiserID).Updates(map[string]interface{}{
+					"current_amount": gorm.Expr("current_amount + ?", change.AmountDelta),
+					"donor_count":    gorm.Expr("donor_count + ?", change.BackerDelta),
+					"updated_at":     time.Now(),
+				}).Error
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for i := range change.Journals {
+			err := tx.Create(&change.Journals[i]).Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return applied, nil
+}
+
 func (r *repository) GetById(transactionId int) (Transaction, error) {
 	var transaction Transaction
 
@@ -86,3 +170,200 @@ func (r *repository) GetAll() ([]Transaction, error) {
 
 	return transactions, nil
 }
+
+// GetPaidByYear selects by settlement time, see Transaction.PaidAt.
+func (r *repository) GetPaidByYear(year int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(1, 0, 0)
+
+	err := r.db.Preload("User").Preload("Campaign").Where("status = ? AND updated_at >= ? AND updated_at < ?", "paid", start, end).Order("user_id asc, id asc").Find(&transactions).Error
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// FindWithoutDonationJournal lists the settled transactions the ledger does
+// not know about yet, ordered by id so the caller can page with afterId.
+func (r *repository) FindWithoutDonationJournal(afterId int, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	journaled := r.db.Table("ledger_journals").Select("1").
+		Where("ledger_journals.type = ? AND ledger_journals.reference_type = ?", ledger.JOURNAL_DONATION, ledger.REFERENCE_TRANSACTION).
+		Where("ledger_journals.reference_id = transactions.id")
+
+	err := r.db.Where("id > ? AND status IN ?", afterId, []string{"paid", "refunded"}).
+		Where("NOT EXISTS (?)", journaled).
+		Order("id asc").
+		Limit(limit).
+		Find(&transactions).Error
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) FindInBatches(input ExportTransactionInput, batchSize int, fn func(transactions []Transaction) error) error {
+	var transactions []Transaction
+
+	query := r.db.Preload("User").Preload("Campaign")
+
+	if !input.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", input.StartDate)
+	}
+
+	if !input.EndDate.IsZero() {
+		query = query.Where("created_at < ?", input.EndDate.AddDate(0, 0, 1))
+	}
+
+	if input.CampaignID != 0 {
+		query = query.Where("campaign_id = ?", input.CampaignID)
+	}
+
+	if input.Status != "" {
+		query = query.Where("status = ?", input.Status)
+	}
+
+	err := query.FindInBatches(&transactions, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(transactions)
+	}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// netAmountSQL and grossAmountSQL compute Transaction.CampaignAmount and
+// Transaction.ChargedAmount in the database.
+const netAmountSQL = "CASE WHEN gross_amount = 0 THEN amount ELSE net_amount END"
+const grossAmountSQL = "CASE WHEN gross_amount = 0 THEN amount ELSE gross_amount END"
+
+// SumPaidSince totals what campaigns received after fees.
+func (r *repository) SumPaidSince(since time.Time) (int, error) {
+	var total int
+
+	err := r.db.Model(&Transaction{}).Select("COALESCE(SUM("+netAmountSQL+"), 0)").Where("status = ? AND created_at >= ?", "paid", since).Scan(&total).Error
+
+	if err != nil {
+		return total, err
+	}
+
+	return total, nil
+}
+
+// SummarizePaidByDonationType totals what campaigns received after fees per
+// type, grouping like Transaction.EffectiveDonationType.
+func (r *repository) SummarizePaidByDonationType() ([]DonationTypeSummary, error) {
+	var summaries []DonationTypeSummary
+
+	donationType := "COALESCE(NULLIF(donation_type, ''), '" + campaign.DONATION_TYPE_SEDEKAH + "')"
+
+	err := r.db.Model(&Transaction{}).Select(donationType+" AS donation_type, COUNT(*) AS count, COALESCE(SUM("+netAmountSQL+"), 0) AS amount").Where("status = ?", "paid").Group(donationType).Order("amount desc").Scan(&summaries).Error
+
+	if err != nil {
+		return summaries, err
+	}
+
+	return summaries, nil
+}
+
+// SummarizeByStatus totals what donors were charged, or asked to pay while
+// the transaction is not paid.
+func (r *repository) SummarizeByStatus() ([]StatusSummary, error) {
+	var summaries []StatusSummary
+
+	err := r.db.Model(&Transaction{}).Select("status, COUNT(*) AS count, COALESCE(SUM(" + grossAmountSQL + "), 0) AS amount").Group("status").Order("count desc").Scan(&summaries).Error
+
+	if err != nil {
+		return summaries, err
+	}
+
+	return summaries, nil
+}
+
+// DailyPaidTotals totals what campaigns received after fees per day.
+func (r *repository) DailyPaidTotals(since time.Time) ([]DailyTotal, error) {
+	var totals []DailyTotal
+
+	err := r.db.Model(&Transaction{}).Select("DATE(created_at) AS date, COUNT(*) AS count, COALESCE(SUM("+netAmountSQL+"), 0) AS amount").Where("status = ? AND created_at >= ?", "paid", since).Group("DATE(created_at)").Order("date asc").Scan(&totals).Error
+
+	if err != nil {
+		return totals, err
+	}
+
+	return totals, nil
+}
+
+var transactionColumns = map[string]string{
+	"campaign_name": "campaigns.name",
+	"user_name":     "users.name",
+	"user_email":    "users.email",
+	"amount":        "transactions.amount",
+	"gross_amount":  "transactions.gross_amount",
+	"net_amount":    "transactions.net_amount",
+	"status":        "transactions.status",
+	"code":          "transactions.code",
+}
+
+func (r *repository) joinedTransactions() *gorm.DB {
+	return r.db.Model(&Transaction{}).
+		Joins("LEFT JOIN users ON users.id = transactions.user_id").
+		Joins("LEFT JOIN campaigns ON campaigns.id = transactions.campaign_id")
+}
+
+func (r *repository) FindPaginated(request datatable.Request) ([]Transaction, int64, int64, error) {
+	var transactions []Transaction
+	var total int64
+	var filtered int64
+
+	err := r.db.Model(&Transaction{}).Count(&total).Error
+	if err != nil {
+		return transactions, total, filtered, err
+	}
+
+	err = request.Filter(r.joinedTransactions(), transactionColumns).Count(&filtered).Error
+	if err != nil {
+		return transactions, total, filtered, err
+	}
+
+	query := request.Filter(r.joinedTransactions(), transactionColumns).Select("transactions.*").Preload("User").Preload("Campaign")
+
+	err = request.Paginate(query, transactionColumns, "transactions.id desc").Find(&transactions).Error
+	if err != nil {
+		return transactions, total, filtered, err
+	}
+
+	return transactions, total, filtered, nil
+}
+
+func (r *repository) GetPaidByCampaignId(campaignId int, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.Preload("User").Where("campaign_id = ? AND status = ?", campaignId, "paid").Order("id desc").Limit(limit).Find(&transactions).Error
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) GetPaidByFundraiserId(fundraiserId int, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.Preload("User").Where("fundraiser_id = ? AND status = ?", fundraiserId, "paid").Order("id desc").Limit(limit).Find(&transactions).Error
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
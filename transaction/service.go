@@ -2,28 +2,47 @@ package transaction
 
 import (
 	"bekasiberbagi/campaign"
+	"bekasiberbagi/datatable"
+	"bekasiberbagi/fundraiser"
+	"bekasiberbagi/ledger"
+	"bekasiberbagi/matching"
+	"bekasiberbagi/milestone"
 	"bekasiberbagi/payment"
 	"errors"
 	"strconv"
 )
 
 type service struct {
-	repository         Repository
-	campaignRepository campaign.Repository
-	paymentService     payment.Service
+	repository           Repository
+	campaignRepository   campaign.Repository
+	fundraiserRepository fundraiser.Repository
+	paymentService       payment.Service
+	ledgerService        ledger.Service
+	matchingService      matching.Service
+	milestoneService     milestone.Service
+	feeSchedule          FeeSchedule
 }
 
 type Service interface {
 	GetTransactionByCampaignId(input GetCampaignTransactionInput) ([]Transaction, error)
 	GetTransactionByUserId(userId int) ([]Transaction, error)
+	GetTransactionById(transactionId int) (Transaction, error)
 	CreateTransaction(input CreateTransactionInput) (Transaction, error)
+	CalculateFee(input GetFeeInput) (FeeBreakdown, error)
 	PaymentNotification(input TransactionNotificationInput) error
+	BackfillLedger() (int, error)
 
 	GetTransactions() ([]Transaction, error)
+	GetStatement(input GetStatementInput) (Statement, error)
+	GetStatementsByYear(year int) ([]Statement, error)
+	ExportTransactions(input ExportTransactionInput, fn func(transactions []Transaction) error) error
+	GetTransactionsPaginated(request datatable.Request) ([]Transaction, int64, int64, error)
+	GetDonorFeed(campaignId int) ([]Transaction, error)
+	GetFundraiserDonorFeed(fundraiserId int) ([]Transaction, error)
 }
 
-func NewService(repository Repository, campaignRepository campaign.Repository, paymentService payment.Service) *service {
-	return &service{repository, campaignRepository, paymentService}
+func NewService(repository Repository, campaignRepository campaign.Repository, fundraiserRepository fundraiser.Repository, paymentService payment.Service, ledgerService ledger.Service, matchingService matching.Service, milestoneService milestone.Service, feeSchedule FeeSchedule) *service {
+	return &service{repository, campaignRepository, fundraiserRepository, paymentService, ledgerService, matchingService, milestoneService, feeSchedule}
 }
 
 func (s *service) GetTransactionByCampaignId(input GetCampaignTransactionInput) ([]Transaction, error) {
@@ -56,10 +75,76 @@ func (s *service) GetTransactionByUserId(userId int) ([]Transaction, error) {
 	return transactions, nil
 }
 
+func (s *service) GetTransactionById(transactionId int) (Transaction, error) {
+	transaction, err := s.repository.GetById(transactionId)
+
+	if err != nil {
+		return transaction, err
+	}
+
+	if transaction.ID == 0 {
+		return transaction, errors.New("TRANSACTION NOT FOUND")
+	}
+
+	return transaction, nil
+}
+
 func (s *service) CreateTransaction(input CreateTransactionInput) (Transaction, error) {
+	campaign, err := s.campaignRepository.FindById(input.CampaignId)
+
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	if !campaign.IsLive() {
+		return Transaction{}, errors.New("CAMPAIGN IS NOT ACCEPTING DONATIONS")
+	}
+
+	donationType, err := campaign.ResolveDonationType(input.DonationType)
+
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	if input.FundraiserID != 0 {
+		fundraiser, err := s.fundraiserRepository.FindById(input.FundraiserID)
+
+		if err != nil {
+			return Transaction{}, err
+		}
+
+		if fundraiser.ID == 0 || fundraiser.CampaignID != campaign.ID {
+			return Transaction{}, errors.New("FUNDRAISER DOES NOT BELONG TO THIS CAMPAIGN")
+		}
+
+		if !fundraiser.IsActive() {
+			return Transaction{}, errors.New("FUNDRAISER IS NOT ACCEPTING DONATIONS")
+		}
+	}
+
+	if !s.feeSchedule.Supports(input.PaymentMethod) {
+		return Transaction{}, errors.New("PAYMENT METHOD IS NOT SUPPORTED")
+	}
+
+	fee := s.feeSchedule.Calculate(input.Amount, input.Tip, input.PaymentMethod, input.CoverFee)
+
+	if fee.Net <= 0 {
+		return Transaction{}, errors.New("AMOUNT IS TOO SMALL TO COVER FEES")
+	}
+
 	transaction := Transaction{}
 	transaction.CampaignID = input.CampaignId
 	transaction.Amount = input.Amount
+	transaction.PaymentMethod = NormalizePaymentMethod(input.PaymentMethod)
+	transaction.CoverFee = input.CoverFee
+	transaction.Tip = fee.Tip
+	transaction.GatewayFee = fee.GatewayFee
+	transaction.PlatformFee = fee.PlatformFee
+	transaction.GrossAmount = fee.Gross
+	transaction.NetAmount = fee.Net
+	transaction.SubscriptionID = input.SubscriptionID
+	transaction.FundraiserID = input.FundraiserID
+	transaction.DonationType = donationType
 	transaction.UserID = input.User.ID
 	transaction.Status = "pending"
 
@@ -69,8 +154,9 @@ func (s *service) CreateTransaction(input CreateTransactionInput) (Transaction,
 	}
 
 	paymentTransaction := payment.Transaction{
-		ID:     newTransaction.ID,
-		Amount: newTransaction.Amount,
+		ID:            newTransaction.ID,
+		Amount:        newTransaction.GrossAmount,
+		PaymentMethod: newTransaction.PaymentMethod,
 	}
 
 	paymentUrl, err := s.paymentService.GetPaymentUrl(paymentTransaction, input.User)
@@ -88,6 +174,14 @@ func (s *service) CreateTransaction(input CreateTransactionInput) (Transaction,
 	return newTransaction, nil
 }
 
+func (s *service) CalculateFee(input GetFeeInput) (FeeBreakdown, error) {
+	if !s.feeSchedule.Supports(input.PaymentMethod) {
+		return FeeBreakdown{}, errors.New("PAYMENT METHOD IS NOT SUPPORTED")
+	}
+
+	return s.feeSchedule.Calculate(input.Amount, input.Tip, input.PaymentMethod, input.CoverFee), nil
+}
+
 func (s *service) PaymentNotification(input TransactionNotificationInput) error {
 	transactionId, err := strconv.Atoi(input.OrderID)
 	if err != nil {
@@ -99,6 +193,8 @@ func (s *service) PaymentNotification(input TransactionNotificationInput) error
 		return err
 	}
 
+	previousStatus := transaction.Status
+
 	if input.PaymentType == "credit_card" && input.TransactionStatus == "capture" && input.FraudStatus == "accept" {
 		transaction.Status = "paid"
 	} else if input.TransactionStatus == "settlement" {
@@ -109,23 +205,65 @@ func (s *service) PaymentNotification(input TransactionNotificationInput) error
 		transaction.Status = "expire"
 	} else if input.TransactionStatus == "cancel" {
 		transaction.Status = "cancelled"
+	} else if input.TransactionStatus == "refund" && previousStatus == "paid" {
+		transaction.Status = "refunded"
+	}
+
+	// Midtrans may repeat a notification, only a change of status moves money.
+	if transaction.Status == previousStatus {
+		return nil
+	}
+
+	change := PaymentChange{PreviousStatus: previousStatus}
+
+	if transaction.Status == "paid" {
+		change.AmountDelta = transaction.CampaignAmount()
+		change.BackerDelta = 1
+		change.Journals, err = s.prepareJournals(donationInputs(transaction)...)
+	}
+
+	if transaction.Status == "refunded" {
+		change.AmountDelta = -transaction.CampaignAmount()
+		change.BackerDelta = -1
+		change.Journals, err = s.prepareJournals(
+			ledger.RefundInput(transaction.CampaignID, transaction.ID, transaction.CampaignAmount()),
+		)
 	}
 
-	updatedTransaction, err := s.repository.Update(transaction)
 	if err != nil {
 		return err
 	}
 
-	campaign, err := s.campaignRepository.FindById(updatedTransaction.CampaignID)
+	applied, err := s.repository.UpdateStatus(transaction, change)
 	if err != nil {
 		return err
 	}
 
-	if updatedTransaction.Status == "paid" {
-		campaign.BackerCount = campaign.BackerCount + 1
-		campaign.CurrentAmount = campaign.CurrentAmount + updatedTransaction.Amount
+	if !applied {
+		return nil
+	}
+
+	if transaction.Status == "paid" {
+		campaign, err := s.campaignRepository.FindById(transaction.CampaignID)
+		if err != nil {
+			return err
+		}
 
-		_, err := s.campaignRepository.Update(campaign)
+		_, err = s.milestoneService.CheckMilestones(campaign, campaign.CurrentAmount-change.AmountDelta)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = s.matchingService.MatchDonation(campaign.ID, transaction.ID, transaction.Amount, transaction.CreatedAt)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if transaction.Status == "refunded" {
+		err = s.matchingService.ReverseDonation(transaction.ID)
 
 		if err != nil {
 			return err
@@ -135,6 +273,85 @@ func (s *service) PaymentNotification(input TransactionNotificationInput) error
 	return nil
 }
 
+// BackfillLedger posts the journals of paid and refunded transactions that
+// were settled before the ledger existed, so their money shows up in the
+// campaign balance. Transactions that already have a donation journal are
+// skipped, which makes it safe to run on every start.
+func (s *service) BackfillLedger() (int, error) {
+	posted := 0
+	lastId := 0
+
+	for {
+		transactions, err := s.repository.FindWithoutDonationJournal(lastId, 500)
+
+		if err != nil {
+			return posted, err
+		}
+
+		if len(transactions) == 0 {
+			return posted, nil
+		}
+
+		for _, transaction := range transactions {
+			lastId = transaction.ID
+
+			inputs := donationInputs(transaction)
+
+			if transaction.Status == "refunded" {
+				refunded, err := s.ledgerService.IsPosted(ledger.JOURNAL_REFUND, ledger.REFERENCE_TRANSACTION, transaction.ID)
+
+				if err != nil {
+					return posted, err
+				}
+
+				if !refunded {
+					inputs = append(inputs, ledger.RefundInput(transaction.CampaignID, transaction.ID, transaction.CampaignAmount()))
+				}
+			}
+
+			_, err := s.ledgerService.PostAll(inputs)
+
+			if err != nil {
+				return posted, err
+			}
+
+			posted++
+		}
+	}
+}
+
+// donationInputs books a paid transaction, the fee journal is only needed
+// when the transaction was charged fees.
+func donationInputs(transaction Transaction) []ledger.PostInput {
+	inputs := []ledger.PostInput{
+		ledger.DonationInput(transaction.CampaignID, transaction.ID, transaction.ChargedAmount(), transaction.Tip),
+	}
+
+	if transaction.FeeAmount() > 0 {
+		inputs = append(inputs, ledger.FeeInput(transaction.CampaignID, transaction.ID, transaction.GatewayFee, transaction.TipGatewayFee(), transaction.PlatformFee))
+	}
+
+	return inputs
+}
+
+// prepareJournals builds the journals of a status change so they are saved
+// in the same database transaction as the new status.
+func (s *service) prepareJournals(inputs ...ledger.PostInput) ([]ledger.LedgerJournal, error) {
+	journals := []ledger.LedgerJournal{}
+
+	for _, input := range inputs {
+		journal, err := s.ledgerService.Prepare(input)
+
+		if err != nil {
+			return journals, err
+		}
+
+		journals = append(journals, journal)
+	}
+
+	return journals, nil
+}
+
 func (s *service) GetTransactions() ([]Transaction, error) {
 	transactions, err := s.repository.GetAll()
 
@@ -144,3 +361,63 @@ func (s *service) GetTransactions() ([]Transaction, error) {
 
 	return transactions, nil
 }
+
+func (s *service) GetStatement(input GetStatementInput) (Statement, error) {
+	transactions, err := s.repository.GetByUserId(input.User.ID)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	return NewStatement(input.Year, input.User, transactions), nil
+}
+
+func (s *service) GetStatementsByYear(year int) ([]Statement, error) {
+	transactions, err := s.repository.GetPaidByYear(year)
+
+	if err != nil {
+		return []Statement{}, err
+	}
+
+	statements := []Statement{}
+	var userTransactions []Transaction
+
+	for i, transaction := range transactions {
+		userTransactions = append(userTransactions, transaction)
+
+		if i == len(transactions)-1 || transactions[i+1].UserID != transaction.UserID {
+			statements = append(statements, NewStatement(year, transaction.User, userTransactions))
+			userTransactions = nil
+		}
+	}
+
+	return statements, nil
+}
+
+func (s *service) ExportTransactions(input ExportTransactionInput, fn func(transactions []Transaction) error) error {
+	return s.repository.FindInBatches(input, 500, fn)
+}
+
+func (s *service) GetTransactionsPaginated(request datatable.Request) ([]Transaction, int64, int64, error) {
+	return s.repository.FindPaginated(request)
+}
+
+func (s *service) GetDonorFeed(campaignId int) ([]Transaction, error) {
+	transactions, err := s.repository.GetPaidByCampaignId(campaignId, 20)
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+func (s *service) GetFundraiserDonorFeed(fundraiserId int) ([]Transaction, error) {
+	transactions, err := s.repository.GetPaidByFundraiserId(fundraiserId, 20)
+
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
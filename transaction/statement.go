@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+
+	"github.com/leekchan/accounting"
+)
+
+// Statement totals what the donor paid for campaigns in a year, see
+// Transaction.DonatedAmount. DonationTypes totals the year per type, zakat
+// is reported apart from other donations when it is claimed against income
+// tax.
+type Statement struct {
+	Year          int
+	User          user.User
+	TotalAmount   int
+	DonationTypes []StatementDonationType
+	Campaigns     []StatementCampaign
+}
+
+type StatementDonationType struct {
+	DonationType string
+	TotalAmount  int
+}
+
+type StatementCampaign struct {
+	CampaignID   int
+	CampaignName string
+	TotalAmount  int
+	Transactions []Transaction
+}
+
+func (s Statement) TotalAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.TotalAmount)
+}
+
+func (s StatementDonationType) Label() string {
+	return campaign.DonationTypeLabel(s.DonationType)
+}
+
+func (s StatementDonationType) TotalAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.TotalAmount)
+}
+
+func (s StatementCampaign) TotalAmountFormatIDR() string {
+	ac := accounting.Accounting{Symbol: "Rp", Precision: 2, Thousand: ".", Decimal: ","}
+
+	return ac.FormatMoney(s.TotalAmount)
+}
+
+func NewStatement(year int, user user.User, transactions []Transaction) Statement {
+	statement := Statement{}
+	statement.Year = year
+	statement.User = user
+	statement.Campaigns = []StatementCampaign{}
+
+	campaignIndex := map[int]int{}
+	totalByType := map[string]int{}
+
+	for _, transaction := range transactions {
+		if transaction.Status != "paid" || transaction.PaidAt().Year() != year {
+			continue
+		}
+
+		index, ok := campaignIndex[transaction.CampaignID]
+
+		if !ok {
+			statementCampaign := StatementCampaign{}
+			statementCampaign.CampaignID = transaction.CampaignID
+			statementCampaign.CampaignName = transaction.Campaign.Name
+
+			statement.Campaigns = append(statement.Campaigns, statementCampaign)
+			index = len(statement.Campaigns) - 1
+			campaignIndex[transaction.CampaignID] = index
+		}
+
+		statement.Campaigns[index].TotalAmount += transaction.DonatedAmount()
+		statement.Campaigns[index].Transactions = append(statement.Campaigns[index].Transactions, transaction)
+		statement.TotalAmount += transaction.DonatedAmount()
+		totalByType[transaction.EffectiveDonationType()] += transaction.DonatedAmount()
+	}
+
+	statement.DonationTypes = []StatementDonationType{}
+
+	for _, donationType := range campaign.DONATION_TYPES {
+		if totalByType[donationType] > 0 {
+			statement.DonationTypes = append(statement.DonationTypes, StatementDonationType{
+				DonationType: donationType,
+				TotalAmount:  totalByType[donationType],
+			})
+		}
+	}
+
+	return statement
+}
@@ -13,3 +13,8 @@ type User struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
+
+type WeeklyCount struct {
+	Week  string
+	Count int
+}
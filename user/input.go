@@ -1,5 +1,7 @@
 package user
 
+import "time"
+
 type RegisterUserInput struct {
 	Name       string `json:"name" binding:"required"`
 	Occupation string `json:"occupation" binding:"required"`
@@ -43,3 +45,9 @@ type WebLoginInput struct {
 	Email    string `form:"email" binding:"required,email"`
 	Password string `form:"password" binding:"required"`
 }
+
+type ExportUserInput struct {
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02"`
+	Format    string    `form:"format"`
+}
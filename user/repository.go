@@ -1,6 +1,11 @@
 package user
 
-import "gorm.io/gorm"
+import (
+	"bekasiberbagi/datatable"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Repository interface {
 	Save(user User) (User, error)
@@ -8,6 +13,9 @@ type Repository interface {
 	FindById(Id int) (User, error)
 	Update(user User) (User, error)
 	FindAll() ([]User, error)
+	FindInBatches(input ExportUserInput, batchSize int, fn func(users []User) error) error
+	CountNewPerWeek(since time.Time) ([]WeeklyCount, error)
+	FindPaginated(request datatable.Request) ([]User, int64, int64, error)
 }
 
 type repository struct {
@@ -72,3 +80,70 @@ func (r *repository) FindAll() ([]User, error) {
 
 	return users, nil
 }
+
+func (r *repository) FindInBatches(input ExportUserInput, batchSize int, fn func(users []User) error) error {
+	var users []User
+
+	query := r.db
+
+	if !input.StartDate.IsZero() {
+		query = query.Where("created_at >= ?", input.StartDate)
+	}
+
+	if !input.EndDate.IsZero() {
+		query = query.Where("created_at < ?", input.EndDate.AddDate(0, 0, 1))
+	}
+
+	err := query.FindInBatches(&users, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(users)
+	}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) CountNewPerWeek(since time.Time) ([]WeeklyCount, error) {
+	var counts []WeeklyCount
+
+	err := r.db.Model(&User{}).Select("DATE_FORMAT(created_at, '%x-W%v') AS week, COUNT(*) AS count").Where("created_at >= ?", since).Group("week").Order("week asc").Scan(&counts).Error
+
+	if err != nil {
+		return counts, err
+	}
+
+	return counts, nil
+}
+
+var userColumns = map[string]string{
+	"name":       "name",
+	"email":      "email",
+	"occupation": "occupation",
+}
+
+func (r *repository) FindPaginated(request datatable.Request) ([]User, int64, int64, error) {
+	var users []User
+	var total int64
+	var filtered int64
+
+	err := r.db.Model(&User{}).Count(&total).Error
+	if err != nil {
+		return users, total, filtered, err
+	}
+
+	err = request.Filter(r.db.Model(&User{}), userColumns).Count(&filtered).Error
+	if err != nil {
+		return users, total, filtered, err
+	}
+
+	query := request.Filter(r.db.Model(&User{}), userColumns)
+
+	err = request.Paginate(query, userColumns, "id desc").Find(&users).Error
+	if err != nil {
+		return users, total, filtered, err
+	}
+
+	return users, total, filtered, nil
+}
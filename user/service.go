@@ -1,6 +1,7 @@
 package user
 
 import (
+	"bekasiberbagi/datatable"
 	"errors"
 
 	"golang.org/x/crypto/bcrypt"
@@ -17,6 +18,8 @@ type Service interface {
 	StoreFromForm(form FormCreateInput) (User, error)
 	UpdateFromForm(form FormUpdateInput) (User, error)
 	UpdateAvatarFromForm(form FormUpdateAvatar) (User, error)
+	ExportUsers(input ExportUserInput, fn func(users []User) error) error
+	GetUsersPaginated(request datatable.Request) ([]User, int64, int64, error)
 }
 
 type service struct {
@@ -208,3 +211,11 @@ func (s *service) WebLogin(input WebLoginInput) (User, error) {
 
 	return user, nil
 }
+
+func (s *service) ExportUsers(input ExportUserInput, fn func(users []User) error) error {
+	return s.repository.FindInBatches(input, 500, fn)
+}
+
+func (s *service) GetUsersPaginated(request datatable.Request) ([]User, int64, int64, error) {
+	return s.repository.FindPaginated(request)
+}
@@ -0,0 +1,67 @@
+package volunteer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const ICAL_CONTENT_TYPE = "text/calendar; charset=utf-8"
+
+const icalTimeFormat = "20060102T150405Z"
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// GenerateICal writes the opportunities as an iCalendar feed (RFC 5545) so
+// volunteers can add their shifts to any calendar app.
+func GenerateICal(opportunities []VolunteerOpportunity, now time.Time) []byte {
+	var builder strings.Builder
+
+	writeICalLine(&builder, "BEGIN:VCALENDAR")
+	writeICalLine(&builder, "VERSION:2.0")
+	writeICalLine(&builder, "PRODID:-//Bekasi Berbagi//Volunteer//ID")
+	writeICalLine(&builder, "CALSCALE:GREGORIAN")
+	writeICalLine(&builder, "METHOD:PUBLISH")
+
+	for _, opportunity := range opportunities {
+		description := opportunity.Description
+
+		if opportunity.Campaign.Name != "" {
+			description = strings.TrimSpace(opportunity.Campaign.Name + "\n\n" + description)
+		}
+
+		writeICalLine(&builder, "BEGIN:VEVENT")
+		writeICalLine(&builder, fmt.Sprintf("UID:volunteer-opportunity-%d@bekasiberbagi", opportunity.ID))
+		writeICalLine(&builder, "DTSTAMP:"+now.UTC().Format(icalTimeFormat))
+		writeICalLine(&builder, "DTSTART:"+opportunity.StartAt.UTC().Format(icalTimeFormat))
+		writeICalLine(&builder, "DTEND:"+opportunity.EndAt.UTC().Format(icalTimeFormat))
+		writeICalLine(&builder, "SUMMARY:"+icalEscaper.Replace(opportunity.Title))
+		writeICalLine(&builder, "LOCATION:"+icalEscaper.Replace(opportunity.Location))
+		writeICalLine(&builder, "DESCRIPTION:"+icalEscaper.Replace(description))
+		writeICalLine(&builder, "END:VEVENT")
+	}
+
+	writeICalLine(&builder, "END:VCALENDAR")
+
+	return []byte(builder.String())
+}
+
+// writeICalLine folds lines longer than 75 octets as the format requires,
+// without splitting a multi-byte character.
+func writeICalLine(builder *strings.Builder, line string) {
+	length := 0
+
+	for _, r := range line {
+		size := len(string(r))
+
+		if length+size > 75 {
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+
+		builder.WriteRune(r)
+		length += size
+	}
+
+	builder.WriteString("\r\n")
+}
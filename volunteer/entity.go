@@ -0,0 +1,72 @@
+package volunteer
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"strings"
+	"time"
+)
+
+const STATUS_CONFIRMED = "confirmed"
+const STATUS_WAITLISTED = "waitlisted"
+const STATUS_CANCELLED = "cancelled"
+
+const ATTENDANCE_PRESENT = "present"
+const ATTENDANCE_ABSENT = "absent"
+
+// VolunteerOpportunity is a shift a campaign needs helpers for, like a
+// distribution day. ConfirmedCount never goes above Capacity, everyone who
+// signs up after that is waitlisted.
+type VolunteerOpportunity struct {
+	ID             int
+	CampaignID     int
+	Title          string
+	Description    string
+	Location       string
+	StartAt        time.Time
+	EndAt          time.Time
+	Capacity       int
+	Skills         string
+	ConfirmedCount int
+	CreatedByID    int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Campaign       campaign.Campaign
+}
+
+func (o VolunteerOpportunity) SkillList() []string {
+	skills := []string{}
+
+	for _, skill := range strings.Split(o.Skills, ",") {
+		if skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+
+	return skills
+}
+
+func (o VolunteerOpportunity) IsFull() bool {
+	return o.ConfirmedCount >= o.Capacity
+}
+
+func (o VolunteerOpportunity) HasStarted(now time.Time) bool {
+	return !now.Before(o.StartAt)
+}
+
+type VolunteerSignup struct {
+	ID            int
+	OpportunityID int
+	UserID        int
+	Status        string
+	Phone         string
+	Note          string
+	Attendance    string
+	CheckedInAt   *time.Time
+	CheckedInByID int
+	CancelledAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Opportunity   VolunteerOpportunity `gorm:"foreignKey:OpportunityID"`
+	User          user.User
+}
@@ -0,0 +1,79 @@
+package volunteer
+
+import "time"
+
+type OpportunityFormatter struct {
+	ID             int       `json:"id"`
+	CampaignID     int       `json:"campaign_id"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	Location       string    `json:"location"`
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+	Capacity       int       `json:"capacity"`
+	ConfirmedCount int       `json:"confirmed_count"`
+	IsFull         bool      `json:"is_full"`
+	Skills         []string  `json:"skills"`
+}
+
+type SignupFormatter struct {
+	ID          int                  `json:"id"`
+	Status      string               `json:"status"`
+	Phone       string               `json:"phone"`
+	Note        string               `json:"note"`
+	Attendance  string               `json:"attendance"`
+	CheckedInAt *time.Time           `json:"checked_in_at"`
+	CreatedAt   time.Time            `json:"created_at"`
+	Opportunity OpportunityFormatter `json:"opportunity"`
+}
+
+func FormatOpportunity(opportunity VolunteerOpportunity) OpportunityFormatter {
+	formatter := OpportunityFormatter{}
+	formatter.ID = opportunity.ID
+	formatter.CampaignID = opportunity.CampaignID
+	formatter.Title = opportunity.Title
+	formatter.Description = opportunity.Description
+	formatter.Location = opportunity.Location
+	formatter.StartAt = opportunity.StartAt
+	formatter.EndAt = opportunity.EndAt
+	formatter.Capacity = opportunity.Capacity
+	formatter.ConfirmedCount = opportunity.ConfirmedCount
+	formatter.IsFull = opportunity.IsFull()
+	formatter.Skills = opportunity.SkillList()
+
+	return formatter
+}
+
+func FormatOpportunities(opportunities []VolunteerOpportunity) []OpportunityFormatter {
+	opportunitiesFormatter := []OpportunityFormatter{}
+
+	for _, opportunity := range opportunities {
+		opportunitiesFormatter = append(opportunitiesFormatter, FormatOpportunity(opportunity))
+	}
+
+	return opportunitiesFormatter
+}
+
+func FormatSignup(signup VolunteerSignup) SignupFormatter {
+	formatter := SignupFormatter{}
+	formatter.ID = signup.ID
+	formatter.Status = signup.Status
+	formatter.Phone = signup.Phone
+	formatter.Note = signup.Note
+	formatter.Attendance = signup.Attendance
+	formatter.CheckedInAt = signup.CheckedInAt
+	formatter.CreatedAt = signup.CreatedAt
+	formatter.Opportunity = FormatOpportunity(signup.Opportunity)
+
+	return formatter
+}
+
+func FormatSignups(signups []VolunteerSignup) []SignupFormatter {
+	signupsFormatter := []SignupFormatter{}
+
+	for _, signup := range signups {
+		signupsFormatter = append(signupsFormatter, FormatSignup(signup))
+	}
+
+	return signupsFormatter
+}
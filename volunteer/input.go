@@ -0,0 +1,44 @@
+package volunteer
+
+import (
+	"bekasiberbagi/user"
+	"time"
+)
+
+type GetCampaignOpportunitiesInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CreateOpportunityInput struct {
+	CampaignID  int
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	Location    string    `json:"location" binding:"required"`
+	StartAt     time.Time `json:"start_at" binding:"required"`
+	EndAt       time.Time `json:"end_at" binding:"required"`
+	Capacity    int       `json:"capacity" binding:"required,gt=0"`
+	Skills      string    `json:"skills"`
+	User        user.User
+}
+
+type GetOpportunityDetailInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type CreateSignupInput struct {
+	OpportunityID int
+	Phone         string `json:"phone" binding:"required"`
+	Note          string `json:"note"`
+	User          user.User
+}
+
+type GetSignupDetailInput struct {
+	ID   int `uri:"id" binding:"required"`
+	User user.User
+}
+
+type FormAttendanceInput struct {
+	ID            int
+	Attendance    string `form:"attendance" binding:"required,oneof=present absent"`
+	CheckedInByID int
+}
@@ -0,0 +1,189 @@
+package volunteer
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	SaveOpportunity(opportunity VolunteerOpportunity) (VolunteerOpportunity, error)
+	FindOpportunityById(opportunityId int) (VolunteerOpportunity, error)
+	FindUpcomingByCampaignID(campaignId int, now time.Time) ([]VolunteerOpportunity, error)
+	FindAllOpportunities() ([]VolunteerOpportunity, error)
+	ReserveSlot(opportunityId int) (bool, error)
+	ReleaseSlot(opportunityId int) error
+	SaveSignup(signup VolunteerSignup) (VolunteerSignup, error)
+	UpdateSignup(signup VolunteerSignup) (VolunteerSignup, error)
+	FindSignupById(signupId int) (VolunteerSignup, error)
+	FindActiveSignup(opportunityId int, userId int) (VolunteerSignup, error)
+	FindFirstWaitlisted(opportunityId int) (VolunteerSignup, error)
+	FindSignupsByOpportunityID(opportunityId int) ([]VolunteerSignup, error)
+	FindSignupsByUserID(userId int) ([]VolunteerSignup, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) SaveOpportunity(opportunity VolunteerOpportunity) (VolunteerOpportunity, error) {
+	err := r.db.Omit("Campaign").Create(&opportunity).Error
+
+	if err != nil {
+		return opportunity, err
+	}
+
+	return opportunity, nil
+}
+
+func (r *repository) FindOpportunityById(opportunityId int) (VolunteerOpportunity, error) {
+	var opportunity VolunteerOpportunity
+
+	err := r.db.Preload("Campaign").Where("id = ?", opportunityId).Find(&opportunity).Error
+
+	if err != nil {
+		return opportunity, err
+	}
+
+	return opportunity, nil
+}
+
+func (r *repository) FindUpcomingByCampaignID(campaignId int, now time.Time) ([]VolunteerOpportunity, error) {
+	var opportunities []VolunteerOpportunity
+
+	err := r.db.Where("campaign_id = ? AND end_at > ?", campaignId, now).Order("start_at asc").Find(&opportunities).Error
+
+	if err != nil {
+		return opportunities, err
+	}
+
+	return opportunities, nil
+}
+
+func (r *repository) FindAllOpportunities() ([]VolunteerOpportunity, error) {
+	var opportunities []VolunteerOpportunity
+
+	err := r.db.Preload("Campaign").Order("start_at desc").Limit(200).Find(&opportunities).Error
+
+	if err != nil {
+		return opportunities, err
+	}
+
+	return opportunities, nil
+}
+
+// ReserveSlot takes one place of the opportunity only while there is one
+// left, so two volunteers signing up at the same time cannot overbook it.
+func (r *repository) ReserveSlot(opportunityId int) (bool, error) {
+	result := r.db.Model(&VolunteerOpportunity{}).
+		Where("id = ? AND confirmed_count < capacity", opportunityId).
+		Updates(map[string]interface{}{
+			"confirmed_count": gorm.Expr("confirmed_count + 1"),
+			"updated_at":      time.Now(),
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *repository) ReleaseSlot(opportunityId int) error {
+	err := r.db.Model(&VolunteerOpportunity{}).
+		Where("id = ? AND confirmed_count > 0", opportunityId).
+		Updates(map[string]interface{}{
+			"confirmed_count": gorm.Expr("confirmed_count - 1"),
+			"updated_at":      time.Now(),
+		}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) SaveSignup(signup VolunteerSignup) (VolunteerSignup, error) {
+	err := r.db.Omit("Opportunity", "User").Create(&signup).Error
+
+	if err != nil {
+		return signup, err
+	}
+
+	return signup, nil
+}
+
+func (r *repository) UpdateSignup(signup VolunteerSignup) (VolunteerSignup, error) {
+	err := r.db.Omit("Opportunity", "User").Save(&signup).Error
+
+	if err != nil {
+		return signup, err
+	}
+
+	return signup, nil
+}
+
+func (r *repository) FindSignupById(signupId int) (VolunteerSignup, error) {
+	var signup VolunteerSignup
+
+	err := r.db.Preload("Opportunity.Campaign").Preload("User").Where("id = ?", signupId).Find(&signup).Error
+
+	if err != nil {
+		return signup, err
+	}
+
+	return signup, nil
+}
+
+func (r *repository) FindActiveSignup(opportunityId int, userId int) (VolunteerSignup, error) {
+	var signup VolunteerSignup
+
+	err := r.db.Where("opportunity_id = ? AND user_id = ? AND status <> ?", opportunityId, userId, STATUS_CANCELLED).Find(&signup).Error
+
+	if err != nil {
+		return signup, err
+	}
+
+	return signup, nil
+}
+
+func (r *repository) FindFirstWaitlisted(opportunityId int) (VolunteerSignup, error) {
+	var signup VolunteerSignup
+
+	err := r.db.Where("opportunity_id = ? AND status = ?", opportunityId, STATUS_WAITLISTED).Order("id asc").Limit(1).Find(&signup).Error
+
+	if err != nil {
+		return signup, err
+	}
+
+	return signup, nil
+}
+
+func (r *repository) FindSignupsByOpportunityID(opportunityId int) ([]VolunteerSignup, error) {
+	var signups []VolunteerSignup
+
+	err := r.db.Preload("User").Where("opportunity_id = ?", opportunityId).Order("id asc").Find(&signups).Error
+
+	if err != nil {
+		return signups, err
+	}
+
+	return signups, nil
+}
+
+func (r *repository) FindSignupsByUserID(userId int) ([]VolunteerSignup, error) {
+	var signups []VolunteerSignup
+
+	err := r.db.Preload("Opportunity.Campaign").Where("user_id = ?", userId).Order("id desc").Find(&signups).Error
+
+	if err != nil {
+		return signups, err
+	}
+
+	return signups, nil
+}
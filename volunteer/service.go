@@ -0,0 +1,308 @@
+package volunteer
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/notification"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Service interface {
+	CreateOpportunity(input CreateOpportunityInput) (VolunteerOpportunity, error)
+	GetOpportunities(input GetCampaignOpportunitiesInput) ([]VolunteerOpportunity, error)
+	GetOpportunityById(opportunityId int) (VolunteerOpportunity, error)
+	GetAllOpportunities() ([]VolunteerOpportunity, error)
+	SignUp(input CreateSignupInput) (VolunteerSignup, error)
+	GetUserSignups(userId int) ([]VolunteerSignup, error)
+	GetSignups(opportunityId int) ([]VolunteerSignup, error)
+	GetSignupById(signupId int) (VolunteerSignup, error)
+	CancelSignup(input GetSignupDetailInput) (VolunteerSignup, error)
+	UpdateAttendance(form FormAttendanceInput) (VolunteerSignup, error)
+}
+
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	notificationService notification.Service
+}
+
+func NewService(repository Repository, campaignRepository campaign.Repository, notificationService notification.Service) *service {
+	return &service{repository, campaignRepository, notificationService}
+}
+
+func (s *service) CreateOpportunity(input CreateOpportunityInput) (VolunteerOpportunity, error) {
+	campaignDetail, err := s.campaignRepository.FindById(input.CampaignID)
+
+	if err != nil {
+		return VolunteerOpportunity{}, err
+	}
+
+	if campaignDetail.ID == 0 || campaignDetail.UserID != input.User.ID {
+		return VolunteerOpportunity{}, errors.New("USER UNAUTHORIZED TO EDIT THIS CAMPAIGN")
+	}
+
+	if input.StartAt.Before(time.Now()) {
+		return VolunteerOpportunity{}, errors.New("START TIME CANNOT BE IN THE PAST")
+	}
+
+	if !input.EndAt.After(input.StartAt) {
+		return VolunteerOpportunity{}, errors.New("END TIME MUST BE AFTER START TIME")
+	}
+
+	opportunity := VolunteerOpportunity{}
+	opportunity.CampaignID = campaignDetail.ID
+	opportunity.Title = input.Title
+	opportunity.Description = input.Description
+	opportunity.Location = input.Location
+	opportunity.StartAt = input.StartAt
+	opportunity.EndAt = input.EndAt
+	opportunity.Capacity = input.Capacity
+	opportunity.Skills = campaign.NormalizeTags(input.Skills)
+	opportunity.CreatedByID = input.User.ID
+
+	newOpportunity, err := s.repository.SaveOpportunity(opportunity)
+
+	if err != nil {
+		return newOpportunity, err
+	}
+
+	newOpportunity.Campaign = campaignDetail
+
+	return newOpportunity, nil
+}
+
+func (s *service) GetOpportunities(input GetCampaignOpportunitiesInput) ([]VolunteerOpportunity, error) {
+	opportunities, err := s.repository.FindUpcomingByCampaignID(input.ID, time.Now())
+
+	if err != nil {
+		return opportunities, err
+	}
+
+	return opportunities, nil
+}
+
+func (s *service) GetOpportunityById(opportunityId int) (VolunteerOpportunity, error) {
+	opportunity, err := s.repository.FindOpportunityById(opportunityId)
+
+	if err != nil {
+		return opportunity, err
+	}
+
+	if opportunity.ID == 0 {
+		return opportunity, errors.New("VOLUNTEER OPPORTUNITY NOT FOUND")
+	}
+
+	return opportunity, nil
+}
+
+func (s *service) GetAllOpportunities() ([]VolunteerOpportunity, error) {
+	opportunities, err := s.repository.FindAllOpportunities()
+
+	if err != nil {
+		return opportunities, err
+	}
+
+	return opportunities, nil
+}
+
+// SignUp confirms the volunteer while the opportunity has room and puts them
+// on the waitlist once it is full.
+func (s *service) SignUp(input CreateSignupInput) (VolunteerSignup, error) {
+	opportunity, err := s.GetOpportunityById(input.OpportunityID)
+
+	if err != nil {
+		return VolunteerSignup{}, err
+	}
+
+	if !opportunity.Campaign.IsLive() || opportunity.HasStarted(time.Now()) {
+		return VolunteerSignup{}, errors.New("VOLUNTEER OPPORTUNITY IS CLOSED")
+	}
+
+	existingSignup, err := s.repository.FindActiveSignup(opportunity.ID, input.User.ID)
+
+	if err != nil {
+		return VolunteerSignup{}, err
+	}
+
+	if existingSignup.ID != 0 {
+		return VolunteerSignup{}, errors.New("ALREADY SIGNED UP FOR THIS OPPORTUNITY")
+	}
+
+	reserved, err := s.repository.ReserveSlot(opportunity.ID)
+
+	if err != nil {
+		return VolunteerSignup{}, err
+	}
+
+	signup := VolunteerSignup{}
+	signup.OpportunityID = opportunity.ID
+	signup.UserID = input.User.ID
+	signup.Phone = input.Phone
+	signup.Note = input.Note
+	signup.Status = STATUS_WAITLISTED
+
+	if reserved {
+		signup.Status = STATUS_CONFIRMED
+	}
+
+	newSignup, err := s.repository.SaveSignup(signup)
+
+	if err != nil {
+		return newSignup, err
+	}
+
+	newSignup.Opportunity = opportunity
+
+	return newSignup, nil
+}
+
+func (s *service) GetUserSignups(userId int) ([]VolunteerSignup, error) {
+	signups, err := s.repository.FindSignupsByUserID(userId)
+
+	if err != nil {
+		return signups, err
+	}
+
+	return signups, nil
+}
+
+func (s *service) GetSignups(opportunityId int) ([]VolunteerSignup, error) {
+	signups, err := s.repository.FindSignupsByOpportunityID(opportunityId)
+
+	if err != nil {
+		return signups, err
+	}
+
+	return signups, nil
+}
+
+func (s *service) GetSignupById(signupId int) (VolunteerSignup, error) {
+	signup, err := s.repository.FindSignupById(signupId)
+
+	if err != nil {
+		return signup, err
+	}
+
+	if signup.ID == 0 {
+		return signup, errors.New("VOLUNTEER SIGNUP NOT FOUND")
+	}
+
+	return signup, nil
+}
+
+// CancelSignup frees the place of a confirmed volunteer and gives it to the
+// first one on the waitlist.
+func (s *service) CancelSignup(input GetSignupDetailInput) (VolunteerSignup, error) {
+	signup, err := s.GetSignupById(input.ID)
+
+	if err != nil {
+		return signup, err
+	}
+
+	if signup.UserID != input.User.ID {
+		return signup, errors.New("VOLUNTEER SIGNUP NOT FOUND")
+	}
+
+	if signup.Status == STATUS_CANCELLED || signup.Opportunity.HasStarted(time.Now()) {
+		return signup, errors.New("SIGNUP CAN NO LONGER BE CANCELLED")
+	}
+
+	wasConfirmed := signup.Status == STATUS_CONFIRMED
+	now := time.Now()
+
+	signup.Status = STATUS_CANCELLED
+	signup.CancelledAt = &now
+
+	updatedSignup, err := s.repository.UpdateSignup(signup)
+
+	if err != nil {
+		return updatedSignup, err
+	}
+
+	if !wasConfirmed {
+		return updatedSignup, nil
+	}
+
+	err = s.repository.ReleaseSlot(signup.OpportunityID)
+
+	if err != nil {
+		return updatedSignup, err
+	}
+
+	err = s.promoteWaitlisted(signup.Opportunity)
+
+	if err != nil {
+		return updatedSignup, err
+	}
+
+	return updatedSignup, nil
+}
+
+func (s *service) promoteWaitlisted(opportunity VolunteerOpportunity) error {
+	waitlisted, err := s.repository.FindFirstWaitlisted(opportunity.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if waitlisted.ID == 0 {
+		return nil
+	}
+
+	reserved, err := s.repository.ReserveSlot(opportunity.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if !reserved {
+		return nil
+	}
+
+	waitlisted.Status = STATUS_CONFIRMED
+
+	_, err = s.repository.UpdateSignup(waitlisted)
+
+	if err != nil {
+		return err
+	}
+
+	s.notificationService.Notify(notification.NotifyInput{
+		UserID:  waitlisted.UserID,
+		Title:   "Volunteer spot confirmed",
+		Message: fmt.Sprintf("A place opened up for %s on %s. See you there!", opportunity.Title, opportunity.StartAt.Format("02 Jan 2006 15:04")),
+		Link:    opportunity.Campaign.PublicPath(),
+	})
+
+	return nil
+}
+
+func (s *service) UpdateAttendance(form FormAttendanceInput) (VolunteerSignup, error) {
+	signup, err := s.GetSignupById(form.ID)
+
+	if err != nil {
+		return signup, err
+	}
+
+	if signup.Status != STATUS_CONFIRMED {
+		return signup, errors.New("ONLY CONFIRMED VOLUNTEERS CAN BE CHECKED IN")
+	}
+
+	signup.Attendance = form.Attendance
+	signup.CheckedInAt = nil
+	signup.CheckedInByID = form.CheckedInByID
+
+	if form.Attendance == ATTENDANCE_PRESENT {
+		now := time.Now()
+		signup.CheckedInAt = &now
+	}
+
+	updatedSignup, err := s.repository.UpdateSignup(signup)
+
+	if err != nil {
+		return updatedSignup, err
+	}
+
+	return updatedSignup, nil
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/datatable"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type auditHandler struct {
+	auditService audit.Service
+}
+
+func NewAuditHandler(auditService audit.Service) *auditHandler {
+	return &auditHandler{
+		auditService: auditService,
+	}
+}
+
+func (h *auditHandler) Index(c *gin.Context) {
+	render(c, http.StatusOK, "audit_index.html", nil)
+}
+
+func (h *auditHandler) Data(c *gin.Context) {
+	var input audit.SearchInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	request := datatable.ParseRequest(c.Request.URL.Query())
+
+	auditLogs, total, filtered, err := h.auditService.Search(input, request)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows := []gin.H{}
+
+	for _, auditLog := range auditLogs {
+		rows = append(rows, gin.H{
+			"id":          auditLog.ID,
+			"created_at":  auditLog.CreatedAt.Format("2006-01-02 15:04:05"),
+			"actor_name":  auditLog.Actor.Name,
+			"action":      auditLog.Action,
+			"entity_type": auditLog.EntityType,
+			"entity_id":   auditLog.EntityID,
+			"changes":     auditLog.Changes,
+			"ip_address":  auditLog.IPAddress,
+			"user_agent":  auditLog.UserAgent,
+		})
+	}
+
+	c.JSON(http.StatusOK, datatable.NewResponse(request, total, filtered, rows))
+}
+
+// recordAudit stores a mutation made by the admin logged in to the current
+// session. Failing to write the audit log does not fail the request.
+func recordAudit(c *gin.Context, auditService audit.Service, action string, entityType string, entityID int, before interface{}, after interface{}) {
+	input := audit.RecordInput{}
+	input.ActorID = currentAdminID(c)
+	input.Action = action
+	input.EntityType = entityType
+	input.EntityID = entityID
+	input.Before = before
+	input.After = after
+	input.IPAddress = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	_, err := auditService.Record(input)
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+}
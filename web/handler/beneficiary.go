@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/beneficiary"
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/user"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type beneficiaryHandler struct {
+	beneficiaryService beneficiary.Service
+	campaignService    campaign.Service
+	userService        user.Service
+	auditService       audit.Service
+}
+
+func NewBeneficiaryHandler(beneficiaryService beneficiary.Service, campaignService campaign.Service, userService user.Service, auditService audit.Service) *beneficiaryHandler {
+	return &beneficiaryHandler{
+		beneficiaryService: beneficiaryService,
+		campaignService:    campaignService,
+		userService:        userService,
+		auditService:       auditService,
+	}
+}
+
+func (h *beneficiaryHandler) Index(c *gin.Context) {
+	var input beneficiary.SearchBeneficiaryInput
+
+	_ = c.ShouldBindQuery(&input)
+
+	beneficiaries, err := h.beneficiaryService.SearchBeneficiaries(input)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "beneficiary_index.html", gin.H{
+		"query":         input.Query,
+		"beneficiaries": beneficiaries,
+	})
+}
+
+func (h *beneficiaryHandler) Create(c *gin.Context) {
+	render(c, http.StatusOK, "beneficiary_create.html", beneficiary.FormBeneficiaryInput{})
+}
+
+func (h *beneficiaryHandler) Store(c *gin.Context) {
+	var form beneficiary.FormBeneficiaryInput
+
+	err := c.ShouldBind(&form)
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "beneficiary_create.html", form)
+		return
+	}
+
+	form.EditorID = currentAdminID(c)
+
+	newBeneficiary, err := h.beneficiaryService.CreateFromForm(form)
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "beneficiary_create.html", form)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "beneficiary", newBeneficiary.ID, nil, newBeneficiary)
+
+	setFlash(c, FLASH_SUCCESS, "Beneficiary has been registered")
+	c.Redirect(http.StatusFound, fmt.Sprintf("/web/beneficiaries/%d", newBeneficiary.ID))
+}
+
+func (h *beneficiaryHandler) Show(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	beneficiaryRegistered, err := h.beneficiaryService.GetBeneficiaryById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	campaigns, err := h.campaignService.GetAllCampaigns()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	users, err := h.userService.GetAllUsers()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "beneficiary_show.html", gin.H{
+		"beneficiary": beneficiaryRegistered,
+		"campaigns":   campaigns,
+		"users":       users,
+	})
+}
+
+func (h *beneficiaryHandler) Edit(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	beneficiaryRegistered, err := h.beneficiaryService.GetBeneficiaryById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	var input beneficiary.FormBeneficiaryInput
+	input.ID = beneficiaryRegistered.ID
+	input.Name = beneficiaryRegistered.Name
+	input.HouseholdSize = beneficiaryRegistered.HouseholdSize
+	input.Phone = beneficiaryRegistered.Phone
+	input.Address = beneficiaryRegistered.Address
+	input.Kecamatan = beneficiaryRegistered.Kecamatan
+	input.Kelurahan = beneficiaryRegistered.Kelurahan
+	input.Needs = beneficiaryRegistered.Needs
+	input.Note = beneficiaryRegistered.Note
+	input.Error = nil
+
+	render(c, http.StatusOK, "beneficiary_edit.html", input)
+}
+
+func (h *beneficiaryHandler) Update(c *gin.Context) {
+	var form beneficiary.FormBeneficiaryInput
+
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	err := c.ShouldBind(&form)
+	form.ID = idParam
+	form.EditorID = currentAdminID(c)
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "beneficiary_edit.html", form)
+		return
+	}
+
+	beneficiaryExists, err := h.beneficiaryService.GetBeneficiaryById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	updatedBeneficiary, err := h.beneficiaryService.UpdateFromForm(form)
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "beneficiary_edit.html", form)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "beneficiary", updatedBeneficiary.ID, beneficiaryExists, updatedBeneficiary)
+
+	setFlash(c, FLASH_SUCCESS, "Beneficiary has been updated")
+	c.Redirect(http.StatusFound, fmt.Sprintf("/web/beneficiaries/%d", updatedBeneficiary.ID))
+}
+
+func (h *beneficiaryHandler) StoreDistribution(c *gin.Context) {
+	var form beneficiary.FormDistributionInput
+
+	err := c.ShouldBind(&form)
+	form.BeneficiaryID, _ = strconv.Atoi(c.Param("id"))
+	form.RecordedByID = currentAdminID(c)
+
+	redirectURL := fmt.Sprintf("/web/beneficiaries/%d", form.BeneficiaryID)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, redirectURL)
+		return
+	}
+
+	newDistribution, err := h.beneficiaryService.RecordDistribution(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, redirectURL)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "distribution", newDistribution.ID, nil, newDistribution)
+
+	setFlash(c, FLASH_SUCCESS, "Distribution has been recorded")
+	c.Redirect(http.StatusFound, redirectURL)
+}
+
+func (h *beneficiaryHandler) Report(c *gin.Context) {
+	report, err := h.beneficiaryService.GetDuplicateReport()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "beneficiary_report.html", gin.H{
+		"report": report,
+		"days":   beneficiary.REPEATED_AID_DAYS,
+	})
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bekasiberbagi/audit"
 	"bekasiberbagi/campaign"
+	"bekasiberbagi/datatable"
+	"bekasiberbagi/disbursement"
+	"bekasiberbagi/matching"
 	"bekasiberbagi/user"
 	"fmt"
 	"net/http"
@@ -11,40 +15,81 @@ import (
 )
 
 type campaignHandler struct {
-	campaignService campaign.Service
-	userService     user.Service
+	campaignService     campaign.Service
+	userService         user.Service
+	auditService        audit.Service
+	disbursementService disbursement.Service
+	matchingService     matching.Service
 }
 
-func NewCampaignHandler(campaignService campaign.Service, userService user.Service) *campaignHandler {
+func NewCampaignHandler(campaignService campaign.Service, userService user.Service, auditService audit.Service, disbursementService disbursement.Service, matchingService matching.Service) *campaignHandler {
 	return &campaignHandler{
-		campaignService: campaignService,
-		userService:     userService,
+		campaignService:     campaignService,
+		userService:         userService,
+		auditService:        auditService,
+		disbursementService: disbursementService,
+		matchingService:     matchingService,
 	}
 }
 
 func (h *campaignHandler) Index(c *gin.Context) {
-	campaigns, err := h.campaignService.GetAllCampaigns()
+	render(c, http.StatusOK, "campaign_index.html", nil)
+}
+
+func (h *campaignHandler) Data(c *gin.Context) {
+	request := datatable.ParseRequest(c.Request.URL.Query())
+
+	campaigns, total, filtered, err := h.campaignService.GetCampaignsPaginated(request)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.HTML(http.StatusOK, "campaign_index.html", gin.H{"campaigns": campaigns})
+	rows := []gin.H{}
+
+	for _, campaign := range campaigns {
+		image := ""
+
+		if len(campaign.CampaignImages) > 0 {
+			image = campaign.CampaignImages[0].FileName
+		}
+
+		rows = append(rows, gin.H{
+			"id":                campaign.ID,
+			"image":             image,
+			"name":              campaign.Name,
+			"short_description": campaign.ShortDescription,
+			"category":          campaign.Category.Name,
+			"status":            campaign.Status,
+			"goal_amount":       campaign.GoalAmountFormatIDR(),
+			"current_amount":    campaign.CurrentAmountFormatIDR(),
+		})
+	}
+
+	c.JSON(http.StatusOK, datatable.NewResponse(request, total, filtered, rows))
 }
 
 func (h *campaignHandler) Create(c *gin.Context) {
 	users, err := h.userService.GetAllUsers()
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	categories, err := h.campaignService.GetCategories()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
 	form := campaign.FormCreateCampaignInput{}
 	form.Users = users
+	form.Categories = categories
 
-	c.HTML(http.StatusOK, "campaign_create.html", form)
+	render(c, http.StatusOK, "campaign_create.html", form)
 }
 
 func (h *campaignHandler) Store(c *gin.Context) {
@@ -52,17 +97,28 @@ func (h *campaignHandler) Store(c *gin.Context) {
 
 	err := c.ShouldBind(&form)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		form.Users, _ = h.userService.GetAllUsers()
+		form.Categories, _ = h.campaignService.GetCategories()
+		render(c, http.StatusUnprocessableEntity, "campaign_create.html", form)
 		return
 	}
 
-	_, err = h.campaignService.CreateFromForm(form)
+	form.EditorID = currentAdminID(c)
+
+	newCampaign, err := h.campaignService.CreateFromForm(form)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		form.Users, _ = h.userService.GetAllUsers()
+		form.Categories, _ = h.campaignService.GetCategories()
+		render(c, http.StatusUnprocessableEntity, "campaign_create.html", form)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "campaign", newCampaign.ID, nil, newCampaign)
+
+	setFlash(c, FLASH_SUCCESS, "Campaign has been created")
 	c.Redirect(http.StatusFound, "/web/campaigns")
 }
 
@@ -72,7 +128,7 @@ func (h *campaignHandler) Edit(c *gin.Context) {
 	campaignRegistered, err := h.campaignService.GetCampaignByIntId(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -84,37 +140,76 @@ func (h *campaignHandler) Edit(c *gin.Context) {
 	input.GoalAmount = campaignRegistered.GoalAmount
 	input.Perks = campaignRegistered.Perks
 	input.UserID = campaignRegistered.UserID
+	input.CategoryID = campaignRegistered.CategoryID
+	input.Tags = campaignRegistered.Tags
+	input.DonationTypes = campaignRegistered.DonationTypeList()
+	input.Kecamatan = campaignRegistered.Kecamatan
+	input.Kelurahan = campaignRegistered.Kelurahan
+	input.Address = campaignRegistered.Address
 	input.Error = nil
 
+	if campaignRegistered.Latitude != nil {
+		input.Latitude = strconv.FormatFloat(*campaignRegistered.Latitude, 'f', -1, 64)
+	}
+
+	if campaignRegistered.Longitude != nil {
+		input.Longitude = strconv.FormatFloat(*campaignRegistered.Longitude, 'f', -1, 64)
+	}
+
 	users, err := h.userService.GetAllUsers()
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 	input.Users = users
 
-	c.HTML(http.StatusOK, "campaign_edit.html", input)
+	categories, err := h.campaignService.GetCategories()
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+	input.Categories = categories
+
+	render(c, http.StatusOK, "campaign_edit.html", input)
 }
 
 func (h *campaignHandler) Update(c *gin.Context) {
 	var form campaign.FormCreateCampaignInput
 
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
 	err := c.ShouldBind(&form)
+	form.ID = idParam
+	form.EditorID = currentAdminID(c)
+
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		form.Users, _ = h.userService.GetAllUsers()
+		form.Categories, _ = h.campaignService.GetCategories()
+		render(c, http.StatusUnprocessableEntity, "campaign_edit.html", form)
 		return
 	}
 
-	idParam, _ := strconv.Atoi(c.Param("id"))
-	form.ID = idParam
+	campaignExists, err := h.campaignService.GetCampaignByIntId(idParam)
 
-	_, err = h.campaignService.UpdateFromForm(form)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	updatedCampaign, err := h.campaignService.UpdateFromForm(form)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		form.Users, _ = h.userService.GetAllUsers()
+		form.Categories, _ = h.campaignService.GetCategories()
+		render(c, http.StatusUnprocessableEntity, "campaign_edit.html", form)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", updatedCampaign.ID, campaignExists, updatedCampaign)
+
+	setFlash(c, FLASH_SUCCESS, "Campaign has been updated")
 	c.Redirect(http.StatusFound, "/web/campaigns")
 }
 
@@ -124,7 +219,7 @@ func (h *campaignHandler) FormUploadImage(c *gin.Context) {
 	campaignRegistered, err := h.campaignService.GetCampaignByIntId(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -141,31 +236,35 @@ func (h *campaignHandler) FormUploadImage(c *gin.Context) {
 	input.Image = primaryImage
 	input.Error = nil
 
-	c.HTML(http.StatusOK, "campaign_image.html", input)
+	render(c, http.StatusOK, "campaign_image.html", input)
 }
 
 func (h *campaignHandler) UploadImage(c *gin.Context) {
 	var form campaign.FormUpdateImage
 
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
 	err := c.ShouldBind(&form)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.ID = idParam
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "campaign_image.html", form)
 		return
 	}
 
 	file, err := c.FormFile("campaign_image")
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.ID = idParam
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "campaign_image.html", form)
 		return
 	}
 
-	idParam, _ := strconv.Atoi(c.Param("id"))
-
 	campaignRegistered, err := h.campaignService.GetCampaignByIntId(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -177,17 +276,21 @@ func (h *campaignHandler) UploadImage(c *gin.Context) {
 
 	err = c.SaveUploadedFile(file, path)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
-	_, err = h.campaignService.UploadImageFromForm(form, path)
+	campaignImage, err := h.campaignService.UploadImageFromForm(form, path)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d/image", idParam))
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "campaign_image", campaignImage.ID, nil, campaignImage)
+
+	setFlash(c, FLASH_SUCCESS, "Campaign image has been uploaded")
 	c.Redirect(http.StatusFound, "/web/campaigns")
 }
 
@@ -197,9 +300,179 @@ func (h *campaignHandler) Show(c *gin.Context) {
 	campaignRegistered, err := h.campaignService.GetCampaignByIntId(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	documents, err := h.campaignService.GetDocuments(idParam)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	documentLinks := []gin.H{}
+
+	for _, document := range documents {
+		documentLinks = append(documentLinks, gin.H{
+			"document": document,
+			"url":      h.campaignService.DocumentURL(document),
+		})
+	}
+
+	balance, err := h.disbursementService.GetBalance(idParam)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	pledges, err := h.matchingService.GetPledges(idParam)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "campaign_show.html", gin.H{"campaign": campaignRegistered, "documents": documentLinks, "balance": balance, "pledges": pledges})
+}
+
+func (h *campaignHandler) Verify(c *gin.Context) {
+	var form campaign.FormVerifyCampaignInput
+
+	err := c.ShouldBind(&form)
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.VerifierID = currentAdminID(c)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.ID))
+		return
+	}
+
+	campaignExists, err := h.campaignService.GetCampaignByIntId(form.ID)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
-	c.HTML(http.StatusOK, "campaign_show.html", campaignRegistered)
+	updatedCampaign, err := h.campaignService.SetVerified(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.ID))
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", updatedCampaign.ID, campaignExists, updatedCampaign)
+
+	if updatedCampaign.IsVerified() {
+		setFlash(c, FLASH_SUCCESS, "Campaign has been marked as verified")
+	} else {
+		setFlash(c, FLASH_SUCCESS, "Campaign verification has been removed")
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.ID))
+}
+
+// Document serves a private verification document. Besides the admin session
+// the link has to carry a valid, unexpired signature from the show page.
+func (h *campaignHandler) Document(c *gin.Context) {
+	var input campaign.GetCampaignDocumentInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		render(c, http.StatusForbidden, "error.html", "Document link is invalid")
+		return
+	}
+
+	input.ID, _ = strconv.Atoi(c.Param("id"))
+
+	document, err := h.campaignService.GetSignedDocument(input)
+	if err != nil {
+		render(c, http.StatusForbidden, "error.html", err.Error())
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", document.OriginalName))
+	c.File(document.FileName)
+}
+
+func (h *campaignHandler) Revisions(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	campaignRegistered, err := h.campaignService.GetCampaignByIntId(idParam)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	revisions, err := h.campaignService.GetRevisions(idParam)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "campaign_revisions.html", gin.H{"campaign": campaignRegistered, "revisions": revisions})
+}
+
+func (h *campaignHandler) CompareRevisions(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+	fromParam, _ := strconv.Atoi(c.Query("from"))
+	toParam, _ := strconv.Atoi(c.Query("to"))
+
+	from, err := h.campaignService.GetRevision(campaign.GetCampaignRevisionInput{ID: idParam, RevisionID: fromParam})
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	to, err := h.campaignService.GetRevision(campaign.GetCampaignRevisionInput{ID: idParam, RevisionID: toParam})
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	render(c, http.StatusOK, "campaign_revision_diff.html", gin.H{
+		"campaignID": idParam,
+		"from":       from,
+		"to":         to,
+		"changes":    campaign.DiffRevisions(from, to),
+	})
+}
+
+func (h *campaignHandler) RollbackRevision(c *gin.Context) {
+	var input campaign.GetCampaignRevisionInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		render(c, http.StatusBadRequest, "error.html", err.Error())
+		return
+	}
+
+	campaignExists, err := h.campaignService.GetCampaignByIntId(input.ID)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	updatedCampaign, err := h.campaignService.RollbackToRevision(input, currentAdminID(c))
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d/revisions", input.ID))
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", updatedCampaign.ID, campaignExists, updatedCampaign)
+
+	setFlash(c, FLASH_SUCCESS, "Campaign has been rolled back")
+	c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d/revisions", input.ID))
 }
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/campaign"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type categoryHandler struct {
+	campaignService campaign.Service
+	auditService    audit.Service
+}
+
+func NewCategoryHandler(campaignService campaign.Service, auditService audit.Service) *categoryHandler {
+	return &categoryHandler{
+		campaignService: campaignService,
+		auditService:    auditService,
+	}
+}
+
+func (h *categoryHandler) Index(c *gin.Context) {
+	categories, err := h.campaignService.GetCategoriesWithCount("")
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "category_index.html", categories)
+}
+
+func (h *categoryHandler) Create(c *gin.Context) {
+	render(c, http.StatusOK, "category_create.html", campaign.FormCategoryInput{})
+}
+
+func (h *categoryHandler) Store(c *gin.Context) {
+	var form campaign.FormCategoryInput
+
+	err := c.ShouldBind(&form)
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "category_create.html", form)
+		return
+	}
+
+	newCategory, err := h.campaignService.CreateCategory(form)
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "category_create.html", form)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "category", newCategory.ID, nil, newCategory)
+
+	setFlash(c, FLASH_SUCCESS, "Category has been created")
+	c.Redirect(http.StatusFound, "/web/categories")
+}
+
+func (h *categoryHandler) Edit(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	category, err := h.campaignService.GetCategoryById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	var form campaign.FormCategoryInput
+	form.ID = category.ID
+	form.Name = category.Name
+	form.Description = category.Description
+
+	render(c, http.StatusOK, "category_edit.html", form)
+}
+
+func (h *categoryHandler) Update(c *gin.Context) {
+	var form campaign.FormCategoryInput
+
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	err := c.ShouldBind(&form)
+	form.ID = idParam
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "category_edit.html", form)
+		return
+	}
+
+	categoryExists, err := h.campaignService.GetCategoryById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	updatedCategory, err := h.campaignService.UpdateCategory(form)
+
+	if err != nil {
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "category_edit.html", form)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "category", updatedCategory.ID, categoryExists, updatedCategory)
+
+	setFlash(c, FLASH_SUCCESS, "Category has been updated")
+	c.Redirect(http.StatusFound, "/web/categories")
+}
+
+func (h *categoryHandler) Delete(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	deletedCategory, err := h.campaignService.DeleteCategory(idParam)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/categories")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_DELETE, "category", deletedCategory.ID, deletedCategory, nil)
+
+	setFlash(c, FLASH_SUCCESS, "Category has been deleted")
+	c.Redirect(http.StatusFound, "/web/categories")
+}
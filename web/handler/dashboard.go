@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"bekasiberbagi/dashboard"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dashboardHandler struct {
+	dashboardService dashboard.Service
+}
+
+func NewDashboardHandler(dashboardService dashboard.Service) *dashboardHandler {
+	return &dashboardHandler{
+		dashboardService: dashboardService,
+	}
+}
+
+func (h *dashboardHandler) Index(c *gin.Context) {
+	dashboard, err := h.dashboardService.GetDashboard()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "dashboard_index.html", dashboard)
+}
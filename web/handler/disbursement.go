@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/disbursement"
+	"bekasiberbagi/notification"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type disbursementHandler struct {
+	disbursementService disbursement.Service
+	notificationService notification.Service
+	auditService        audit.Service
+}
+
+func NewDisbursementHandler(disbursementService disbursement.Service, notificationService notification.Service, auditService audit.Service) *disbursementHandler {
+	return &disbursementHandler{
+		disbursementService: disbursementService,
+		notificationService: notificationService,
+		auditService:        auditService,
+	}
+}
+
+func (h *disbursementHandler) Index(c *gin.Context) {
+	disbursements, err := h.disbursementService.GetDisbursements()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "disbursement_index.html", disbursements)
+}
+
+func (h *disbursementHandler) Approve(c *gin.Context) {
+	var form disbursement.FormReviewDisbursementInput
+
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.ReviewerID = currentAdminID(c)
+
+	disbursementExists, err := h.disbursementService.GetDisbursementById(form.ID)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	file, err := c.FormFile("proof")
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, "Transfer proof is required")
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	// Proofs are kept with the campaign documents and take the same files.
+	if !campaign.IsAllowedDocument(file.Filename) {
+		setFlash(c, FLASH_ERROR, "Transfer proof must be a pdf, jpg or png file")
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	err = os.MkdirAll(disbursement.PROOF_STORAGE_PATH, 0700)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	path := fmt.Sprintf("%s/%d-%d%s", disbursement.PROOF_STORAGE_PATH, form.ID, time.Now().UnixNano(), strings.ToLower(filepath.Ext(file.Filename)))
+
+	err = c.SaveUploadedFile(file, path)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	approvedDisbursement, err := h.disbursementService.ApproveDisbursement(form, path)
+
+	if err != nil {
+		os.Remove(path)
+
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "disbursement", approvedDisbursement.ID, disbursementExists, approvedDisbursement)
+
+	h.notificationService.Notify(notification.NotifyInput{
+		UserID:  approvedDisbursement.UserID,
+		Title:   "Disbursement approved",
+		Message: fmt.Sprintf("Your withdrawal of %s from %s has been transferred.", approvedDisbursement.AmountFormatIDR(), approvedDisbursement.Campaign.Name),
+	})
+
+	setFlash(c, FLASH_SUCCESS, "Disbursement has been approved")
+	c.Redirect(http.StatusFound, "/web/disbursements")
+}
+
+func (h *disbursementHandler) Reject(c *gin.Context) {
+	var form disbursement.FormReviewDisbursementInput
+
+	err := c.ShouldBind(&form)
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.ReviewerID = currentAdminID(c)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	disbursementExists, err := h.disbursementService.GetDisbursementById(form.ID)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	rejectedDisbursement, err := h.disbursementService.RejectDisbursement(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/disbursements")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "disbursement", rejectedDisbursement.ID, disbursementExists, rejectedDisbursement)
+
+	h.notificationService.Notify(notification.NotifyInput{
+		UserID:  rejectedDisbursement.UserID,
+		Title:   "Disbursement rejected",
+		Message: fmt.Sprintf("Your withdrawal of %s from %s has been rejected: %s", rejectedDisbursement.AmountFormatIDR(), rejectedDisbursement.Campaign.Name, rejectedDisbursement.RejectionReason),
+	})
+
+	setFlash(c, FLASH_SUCCESS, "Disbursement has been rejected")
+	c.Redirect(http.StatusFound, "/web/disbursements")
+}
+
+func (h *disbursementHandler) Proof(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	approvedDisbursement, err := h.disbursementService.GetDisbursementById(idParam)
+
+	if err != nil || approvedDisbursement.ProofFileName == "" {
+		render(c, http.StatusNotFound, "error.html", "Transfer proof not found")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.File(approvedDisbursement.ProofFileName)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/export"
+	"bekasiberbagi/transaction"
+	"bekasiberbagi/user"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type exportHandler struct {
+	transactionService transaction.Service
+	campaignService    campaign.Service
+	userService        user.Service
+}
+
+func NewExportHandler(transactionService transaction.Service, campaignService campaign.Service, userService user.Service) *exportHandler {
+	return &exportHandler{
+		transactionService: transactionService,
+		campaignService:    campaignService,
+		userService:        userService,
+	}
+}
+
+func (h *exportHandler) Transactions(c *gin.Context) {
+	var input transaction.ExportTransactionInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	writer, err := h.startExport(c, "transactions", input.Format)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	err = writer.Write([]interface{}{"ID", "Date", "Campaign ID", "Campaign", "Donor ID", "Donor", "Donor Email", "Amount", "Donation Type", "Payment Method", "Tip", "Gateway Fee", "Platform Fee", "Gross", "Net", "Status", "Code"})
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = h.transactionService.ExportTransactions(input, func(transactions []transaction.Transaction) error {
+		for _, transaction := range transactions {
+			err := writer.Write([]interface{}{
+				transaction.ID,
+				transaction.CreatedAt.Format("2006-01-02 15:04:05"),
+				transaction.CampaignID,
+				transaction.Campaign.Name,
+				transaction.UserID,
+				transaction.User.Name,
+				transaction.User.Email,
+				transaction.Amount,
+				transaction.EffectiveDonationType(),
+				transaction.PaymentMethod,
+				transaction.Tip,
+				transaction.GatewayFee,
+				transaction.PlatformFee,
+				transaction.ChargedAmount(),
+				transaction.CampaignAmount(),
+				transaction.Status,
+				transaction.Code,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = writer.Close()
+
+	if err != nil {
+		abortDownload(c, err)
+	}
+}
+
+func (h *exportHandler) Campaigns(c *gin.Context) {
+	var input campaign.ExportCampaignInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	writer, err := h.startExport(c, "campaigns", input.Format)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	err = writer.Write([]interface{}{"ID", "Created At", "Name", "Organizer", "Goal Amount", "Current Amount", "Backer Count", "Funded %"})
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = h.campaignService.ExportCampaigns(input, func(campaigns []campaign.Campaign) error {
+		for _, campaign := range campaigns {
+			err := writer.Write([]interface{}{
+				campaign.ID,
+				campaign.CreatedAt.Format("2006-01-02 15:04:05"),
+				campaign.Name,
+				campaign.User.Name,
+				campaign.GoalAmount,
+				campaign.CurrentAmount,
+				campaign.BackerCount,
+				campaign.FundedPercentage(),
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = writer.Close()
+
+	if err != nil {
+		abortDownload(c, err)
+	}
+}
+
+func (h *exportHandler) Users(c *gin.Context) {
+	var input user.ExportUserInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	writer, err := h.startExport(c, "users", input.Format)
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	err = writer.Write([]interface{}{"ID", "Registered At", "Name", "Email", "Occupation", "Role"})
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = h.userService.ExportUsers(input, func(users []user.User) error {
+		for _, user := range users {
+			err := writer.Write([]interface{}{
+				user.ID,
+				user.CreatedAt.Format("2006-01-02 15:04:05"),
+				user.Name,
+				user.Email,
+				user.Occupation,
+				user.Role,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		abortDownload(c, err)
+		return
+	}
+
+	err = writer.Close()
+
+	if err != nil {
+		abortDownload(c, err)
+	}
+}
+
+func (h *exportHandler) startExport(c *gin.Context, name string, format string) (export.Writer, error) {
+	if format == "" {
+		format = export.FORMAT_CSV
+	}
+
+	if format != export.FORMAT_CSV && format != export.FORMAT_XLSX {
+		return nil, errors.New("UNSUPPORTED EXPORT FORMAT")
+	}
+
+	fileName := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102150405"), format)
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return export.NewWriter(format, c.Writer)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/fundraiser"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fundraiserHandler struct {
+	fundraiserService fundraiser.Service
+	auditService      audit.Service
+}
+
+func NewFundraiserHandler(fundraiserService fundraiser.Service, auditService audit.Service) *fundraiserHandler {
+	return &fundraiserHandler{
+		fundraiserService: fundraiserService,
+		auditService:      auditService,
+	}
+}
+
+func (h *fundraiserHandler) Index(c *gin.Context) {
+	fundraisers, err := h.fundraiserService.GetFundraisers()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "fundraiser_index.html", fundraisers)
+}
+
+func (h *fundraiserHandler) UpdateStatus(c *gin.Context) {
+	var form fundraiser.FormStatusFundraiserInput
+
+	err := c.ShouldBind(&form)
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/fundraisers")
+		return
+	}
+
+	fundraiserExists, err := h.fundraiserService.GetFundraiserById(form.ID)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	updatedFundraiser, err := h.fundraiserService.UpdateStatus(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/fundraisers")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "fundraiser", updatedFundraiser.ID, fundraiserExists, updatedFundraiser)
+
+	setFlash(c, FLASH_SUCCESS, "Fundraiser has been updated")
+	c.Redirect(http.StatusFound, "/web/fundraisers")
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/goods"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type goodsHandler struct {
+	goodsService goods.Service
+	auditService audit.Service
+}
+
+func NewGoodsHandler(goodsService goods.Service, auditService audit.Service) *goodsHandler {
+	return &goodsHandler{
+		goodsService: goodsService,
+		auditService: auditService,
+	}
+}
+
+func (h *goodsHandler) Index(c *gin.Context) {
+	pledges, err := h.goodsService.GetPledges()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "goods_index.html", pledges)
+}
+
+func (h *goodsHandler) Receive(c *gin.Context) {
+	var form goods.FormReceivePledgeInput
+
+	err := c.ShouldBind(&form)
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.ReceiverID = currentAdminID(c)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/goods")
+		return
+	}
+
+	pledgeExists, err := h.goodsService.GetPledgeById(form.ID)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	receivedPledge, err := h.goodsService.ReceivePledge(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/goods")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "goods_pledge", receivedPledge.ID, pledgeExists, receivedPledge)
+
+	setFlash(c, FLASH_SUCCESS, "Goods have been marked as received")
+	c.Redirect(http.StatusFound, "/web/goods")
+}
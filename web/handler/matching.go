@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/matching"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type matchingHandler struct {
+	matchingService matching.Service
+	auditService    audit.Service
+}
+
+func NewMatchingHandler(matchingService matching.Service, auditService audit.Service) *matchingHandler {
+	return &matchingHandler{
+		matchingService: matchingService,
+		auditService:    auditService,
+	}
+}
+
+func (h *matchingHandler) Store(c *gin.Context) {
+	var form matching.FormCreatePledgeInput
+
+	err := c.ShouldBind(&form)
+	form.CampaignID, _ = strconv.Atoi(c.Param("id"))
+	form.CreatedByID = currentAdminID(c)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.CampaignID))
+		return
+	}
+
+	newPledge, err := h.matchingService.CreatePledge(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.CampaignID))
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "matching_pledge", newPledge.ID, nil, newPledge)
+
+	setFlash(c, FLASH_SUCCESS, "Matching pledge has been added")
+	c.Redirect(http.StatusFound, fmt.Sprintf("/web/campaigns/%d", form.CampaignID))
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/notification"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type moderationHandler struct {
+	campaignService     campaign.Service
+	notificationService notification.Service
+	auditService        audit.Service
+}
+
+func NewModerationHandler(campaignService campaign.Service, notificationService notification.Service, auditService audit.Service) *moderationHandler {
+	return &moderationHandler{
+		campaignService:     campaignService,
+		notificationService: notificationService,
+		auditService:        auditService,
+	}
+}
+
+func (h *moderationHandler) Index(c *gin.Context) {
+	campaigns, err := h.campaignService.GetPendingCampaigns()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "moderation_index.html", campaigns)
+}
+
+func (h *moderationHandler) Approve(c *gin.Context) {
+	h.decide(c, true)
+}
+
+func (h *moderationHandler) Reject(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *moderationHandler) decide(c *gin.Context, approve bool) {
+	var form campaign.FormModerationInput
+
+	err := c.ShouldBind(&form)
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/moderation")
+		return
+	}
+
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.ReviewerID = currentAdminID(c)
+
+	campaignExists, err := h.campaignService.GetCampaignByIntId(form.ID)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	var moderatedCampaign campaign.Campaign
+
+	if approve {
+		moderatedCampaign, err = h.campaignService.ApproveCampaign(form)
+	} else {
+		moderatedCampaign, err = h.campaignService.RejectCampaign(form)
+	}
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, "/web/moderation")
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "campaign", moderatedCampaign.ID, campaignExists, moderatedCampaign)
+
+	h.notifyOrganizer(moderatedCampaign)
+
+	setFlash(c, FLASH_SUCCESS, fmt.Sprintf("Campaign %s has been %s", moderatedCampaign.Name, moderatedCampaign.Status))
+	c.Redirect(http.StatusFound, "/web/moderation")
+}
+
+// notifyOrganizer tells the campaign owner about the decision. Like audit
+// records, a failed notification does not undo the stored decision.
+func (h *moderationHandler) notifyOrganizer(moderatedCampaign campaign.Campaign) {
+	input := notification.NotifyInput{UserID: moderatedCampaign.UserID}
+
+	if moderatedCampaign.IsLive() {
+		input.Title = "Campaign approved"
+		input.Message = fmt.Sprintf("Your campaign %s has been approved and is now live.", moderatedCampaign.Name)
+		input.Link = moderatedCampaign.PublicPath()
+	} else {
+		input.Title = "Campaign rejected"
+		input.Message = fmt.Sprintf("Your campaign %s has been rejected: %s", moderatedCampaign.Name, moderatedCampaign.ModerationReason)
+	}
+
+	h.notificationService.Notify(input)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"bekasiberbagi/appurl"
+	"bekasiberbagi/campaign"
+	"bekasiberbagi/expense"
+	"bekasiberbagi/fundraiser"
+	"bekasiberbagi/transaction"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type publicHandler struct {
+	campaignService    campaign.Service
+	transactionService transaction.Service
+	expenseService     expense.Service
+	fundraiserService  fundraiser.Service
+	detailLoader       campaign.DetailLoader
+}
+
+func NewPublicHandler(campaignService campaign.Service, transactionService transaction.Service, expenseService expense.Service, fundraiserService fundraiser.Service, detailLoader campaign.DetailLoader) *publicHandler {
+	return &publicHandler{
+		campaignService:    campaignService,
+		transactionService: transactionService,
+		expenseService:     expenseService,
+		fundraiserService:  fundraiserService,
+		detailLoader:       detailLoader,
+	}
+}
+
+func (h *publicHandler) Campaign(c *gin.Context) {
+	campaignDetail, ok := h.findCampaign(c, "")
+	if !ok {
+		return
+	}
+
+	donors, err := h.transactionService.GetDonorFeed(campaignDetail.ID)
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return
+	}
+
+	expenses, err := h.expenseService.GetExpenses(expense.GetCampaignExpensesInput{ID: campaignDetail.ID})
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return
+	}
+
+	err = h.detailLoader.LoadDetails(&campaignDetail)
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return
+	}
+
+	fundraisers, err := h.fundraiserService.GetLeaderboard(fundraiser.GetCampaignFundraisersInput{ID: campaignDetail.ID})
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return
+	}
+
+	renderPublic(c, http.StatusOK, "public_campaign.html", gin.H{
+		"campaign":    campaignDetail,
+		"donors":      donors,
+		"expenses":    expenses,
+		"fundraisers": fundraisers,
+		"url":         appurl.Absolute(c, campaignDetail.PublicPath()),
+		"imageUrl":    appurl.Absolute(c, campaignDetail.PublicPath()+"/share.png"),
+	})
+}
+
+func (h *publicHandler) Fundraiser(c *gin.Context) {
+	var input fundraiser.GetFundraiserBySlugInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		renderPublic(c, http.StatusNotFound, "public_not_found.html", nil)
+		return
+	}
+
+	fundraiserDetail, err := h.fundraiserService.GetFundraiserBySlug(input)
+	if err != nil {
+		renderPublic(c, http.StatusNotFound, "public_not_found.html", nil)
+		return
+	}
+
+	donors, err := h.transactionService.GetFundraiserDonorFeed(fundraiserDetail.ID)
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return
+	}
+
+	renderPublic(c, http.StatusOK, "public_fundraiser.html", gin.H{
+		"fundraiser": fundraiserDetail,
+		"donors":     donors,
+		"url":        appurl.Absolute(c, fundraiserDetail.PublicPath()),
+		"imageUrl":   appurl.Absolute(c, fundraiserDetail.Campaign.PublicPath()+"/share.png"),
+	})
+}
+
+func (h *publicHandler) ShareImage(c *gin.Context) {
+	campaignDetail, ok := h.findCampaign(c, "/share.png")
+	if !ok {
+		return
+	}
+
+	image, err := campaign.ShareImage(campaignDetail)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=600")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// findCampaign resolves the slug of the current request and answers with a
+// not found page or a permanent redirect when the slug is unknown or old.
+func (h *publicHandler) findCampaign(c *gin.Context, suffix string) (campaign.Campaign, bool) {
+	var input campaign.GetCampaignBySlugInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		renderPublic(c, http.StatusNotFound, "public_not_found.html", nil)
+		return campaign.Campaign{}, false
+	}
+
+	campaignDetail, moved, err := h.campaignService.GetCampaignBySlug(input)
+	if err == campaign.ErrCampaignNotFound {
+		renderPublic(c, http.StatusNotFound, "public_not_found.html", nil)
+		return campaignDetail, false
+	}
+
+	if err != nil {
+		renderPublic(c, http.StatusInternalServerError, "public_not_found.html", nil)
+		return campaignDetail, false
+	}
+
+	if moved {
+		c.Redirect(http.StatusMovedPermanently, campaignDetail.PublicPath()+suffix)
+		return campaignDetail, false
+	}
+
+	return campaignDetail, true
+}
+
+func (h *publicHandler) Embed(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	campaignDetail, err := h.campaignService.GetCampaignByIntId(idParam)
+	if err != nil || campaignDetail.ID == 0 || !campaignDetail.IsLive() {
+		renderPublic(c, http.StatusNotFound, "embed_not_found.html", nil)
+		return
+	}
+
+	renderPublic(c, http.StatusOK, "embed_campaign.html", gin.H{
+		"campaign": campaignDetail,
+		"url":      appurl.Absolute(c, campaignDetail.PublicPath()) + "?utm_source=widget&utm_medium=embed",
+	})
+}
+
+func (h *publicHandler) EmbedScript(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	embedURL := appurl.Absolute(c, fmt.Sprintf("/embed/campaigns/%d", idParam))
+
+	script := fmt.Sprintf(`(function () {
+	var script = document.currentScript;
+	var iframe = document.createElement('iframe');
+	iframe.src = %q;
+	iframe.width = '100%%';
+	iframe.height = '320';
+	iframe.style.border = '0';
+	iframe.setAttribute('loading', 'lazy');
+	iframe.setAttribute('title', 'BEKASIBERBAGI');
+	script.parentNode.insertBefore(iframe, script);
+})();
+`, embedURL)
+
+	c.Header("Cache-Control", "public, max-age=600")
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(script))
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"log"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+const FLASH_SUCCESS = "success"
+const FLASH_ERROR = "error"
+
+type View struct {
+	Data      interface{}
+	CsrfToken string
+	Flashes   []Flash
+}
+
+type Flash struct {
+	Type    string
+	Message string
+}
+
+// render wraps the handler data with the CSRF token and pending flash
+// messages so every admin template gets them through the base layout.
+func render(c *gin.Context, code int, name string, data interface{}) {
+	session := sessions.Default(c)
+
+	view := View{}
+	view.Data = data
+	view.CsrfToken = c.GetString("csrfToken")
+	view.Flashes = []Flash{}
+
+	for _, flashType := range []string{FLASH_SUCCESS, FLASH_ERROR} {
+		for _, message := range session.Flashes(flashType) {
+			view.Flashes = append(view.Flashes, Flash{Type: flashType, Message: message.(string)})
+		}
+	}
+
+	session.Save()
+
+	c.HTML(code, name, view)
+}
+
+// renderPublic is render for the pages guests and embedding sites load. It
+// leaves the session alone, so those pages set no cookie and do not consume
+// the flash messages meant for an admin in the same browser.
+func renderPublic(c *gin.Context, code int, name string, data interface{}) {
+	view := View{}
+	view.Data = data
+	view.Flashes = []Flash{}
+
+	c.HTML(code, name, view)
+}
+
+func setFlash(c *gin.Context, flashType string, message string) {
+	session := sessions.Default(c)
+	session.AddFlash(message, flashType)
+	session.Save()
+}
+
+func currentAdminID(c *gin.Context) int {
+	session := sessions.Default(c)
+
+	userId, _ := session.Get("userId").(int)
+
+	return userId
+}
+
+// abortDownload logs why a download failed after its headers were sent and
+// drops the connection, so the browser reports a failed download instead of
+// saving a truncated file that looks complete.
+func abortDownload(c *gin.Context, err error) {
+	log.Println(err.Error())
+
+	c.Abort()
+
+	conn, _, err := c.Writer.Hijack()
+
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	conn.Close()
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"bekasiberbagi/subscription"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type subscriptionHandler struct {
+	subscriptionService subscription.Service
+}
+
+func NewSubscriptionHandler(subscriptionService subscription.Service) *subscriptionHandler {
+	return &subscriptionHandler{subscriptionService}
+}
+
+func (h *subscriptionHandler) Index(c *gin.Context) {
+	subscriptions, err := h.subscriptionService.GetSubscriptions()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "subscription_index.html", subscriptions)
+}
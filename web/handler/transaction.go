@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"archive/zip"
+	"bekasiberbagi/datatable"
 	"bekasiberbagi/transaction"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,12 +23,86 @@ func NewTransactionHandler(transactionService transaction.Service) *transactionH
 }
 
 func (h *transactionHandler) Index(c *gin.Context) {
-	transactions, err := h.transactionService.GetTransactions()
+	render(c, http.StatusOK, "transaction_index.html", gin.H{"year": time.Now().Year()})
+}
+
+func (h *transactionHandler) Data(c *gin.Context) {
+	request := datatable.ParseRequest(c.Request.URL.Query())
+
+	transactions, total, filtered, err := h.transactionService.GetTransactionsPaginated(request)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows := []gin.H{}
+
+	for _, transaction := range transactions {
+		rows = append(rows, gin.H{
+			"id":            transaction.ID,
+			"campaign_name": transaction.Campaign.Name,
+			"user_name":     transaction.User.Name,
+			"user_email":    transaction.User.Email,
+			"amount":        transaction.AmountFormatIDR(),
+			"gross_amount":  transaction.FormatIDR(transaction.ChargedAmount()),
+			"fee_amount":    transaction.FormatIDR(transaction.FeeAmount()),
+			"net_amount":    transaction.FormatIDR(transaction.CampaignAmount()),
+			"status":        transaction.Status,
+			"code":          transaction.Code,
+			"payment_url":   transaction.PaymentUrl,
+		})
+	}
+
+	c.JSON(http.StatusOK, datatable.NewResponse(request, total, filtered, rows))
+}
+
+func (h *transactionHandler) Statements(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	statements, err := h.transactionService.GetStatementsByYear(year)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
-	c.HTML(http.StatusOK, "transaction_index.html", gin.H{"transactions": transactions})
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=statements-%d.zip", year))
+
+	archive := zip.NewWriter(c.Writer)
+
+	for _, statement := range statements {
+		pdf, err := transaction.GenerateStatementPdf(statement)
+
+		if err != nil {
+			abortDownload(c, err)
+			return
+		}
+
+		file, err := archive.Create(fmt.Sprintf("statement-%d-%d.pdf", year, statement.User.ID))
+
+		if err != nil {
+			abortDownload(c, err)
+			return
+		}
+
+		_, err = file.Write(pdf)
+
+		if err != nil {
+			abortDownload(c, err)
+			return
+		}
+	}
+
+	err = archive.Close()
+
+	if err != nil {
+		abortDownload(c, err)
+	}
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/datatable"
 	"bekasiberbagi/user"
 	"fmt"
 	"net/http"
@@ -10,26 +12,45 @@ import (
 )
 
 type userHandler struct {
-	userService user.Service
+	userService  user.Service
+	auditService audit.Service
 }
 
-func NewUserHandler(userService user.Service) *userHandler {
-	return &userHandler{userService}
+func NewUserHandler(userService user.Service, auditService audit.Service) *userHandler {
+	return &userHandler{userService, auditService}
 }
 
 func (h *userHandler) Index(c *gin.Context) {
-	users, err := h.userService.GetAllUsers()
+	render(c, http.StatusOK, "index.html", nil)
+}
+
+func (h *userHandler) Data(c *gin.Context) {
+	request := datatable.ParseRequest(c.Request.URL.Query())
+
+	users, total, filtered, err := h.userService.GetUsersPaginated(request)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.HTML(http.StatusOK, "index.html", gin.H{"users": users})
+	rows := []gin.H{}
+
+	for _, user := range users {
+		rows = append(rows, gin.H{
+			"id":         user.ID,
+			"avatar":     user.AvatarFileName,
+			"name":       user.Name,
+			"email":      user.Email,
+			"occupation": user.Occupation,
+		})
+	}
+
+	c.JSON(http.StatusOK, datatable.NewResponse(request, total, filtered, rows))
 }
 
 func (h *userHandler) Create(c *gin.Context) {
-	c.HTML(http.StatusOK, "create.html", nil)
+	render(c, http.StatusOK, "create.html", nil)
 }
 
 func (h *userHandler) Store(c *gin.Context) {
@@ -38,17 +59,21 @@ func (h *userHandler) Store(c *gin.Context) {
 	err := c.ShouldBind(&form)
 	if err != nil {
 		form.Error = err
-		c.HTML(http.StatusInternalServerError, "create.html", form)
+		render(c, http.StatusUnprocessableEntity, "create.html", form)
 		return
 	}
 
-	_, err = h.userService.StoreFromForm(form)
+	newUser, err := h.userService.StoreFromForm(form)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "create.html", form)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_CREATE, "user", newUser.ID, nil, newUser)
+
+	setFlash(c, FLASH_SUCCESS, "User has been created")
 	c.Redirect(http.StatusFound, "/web/users")
 }
 
@@ -58,7 +83,7 @@ func (h *userHandler) Edit(c *gin.Context) {
 	registeredUser, err := h.userService.GetUserById(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -69,7 +94,7 @@ func (h *userHandler) Edit(c *gin.Context) {
 	input.Occupation = registeredUser.Occupation
 	input.Error = nil
 
-	c.HTML(http.StatusOK, "edit.html", input)
+	render(c, http.StatusOK, "edit.html", input)
 }
 
 func (h *userHandler) Update(c *gin.Context) {
@@ -77,27 +102,31 @@ func (h *userHandler) Update(c *gin.Context) {
 
 	userExists, err := h.userService.GetUserById(idParam)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
 	var form user.FormUpdateInput
 
 	err = c.ShouldBind(&form)
+	form.ID = idParam
+
 	if err != nil {
 		form.Error = err
-		c.HTML(http.StatusInternalServerError, "edit.html", userExists)
+		render(c, http.StatusUnprocessableEntity, "edit.html", form)
 		return
 	}
 
-	form.ID = idParam
-
-	_, err = h.userService.UpdateFromForm(form)
+	updatedUser, err := h.userService.UpdateFromForm(form)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "edit.html", form)
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "user", updatedUser.ID, userExists, updatedUser)
+
+	setFlash(c, FLASH_SUCCESS, "User has been updated")
 	c.Redirect(http.StatusFound, "/web/users")
 }
 
@@ -107,7 +136,7 @@ func (h *userHandler) EditAvatar(c *gin.Context) {
 	registeredUser, err := h.userService.GetUserById(idParam)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -117,7 +146,7 @@ func (h *userHandler) EditAvatar(c *gin.Context) {
 	input.AvatarFileName = registeredUser.AvatarFileName
 	input.Error = nil
 
-	c.HTML(http.StatusOK, "edit_avatar.html", input)
+	render(c, http.StatusOK, "edit_avatar.html", input)
 }
 
 func (h *userHandler) UpdateAvatar(c *gin.Context) {
@@ -125,14 +154,19 @@ func (h *userHandler) UpdateAvatar(c *gin.Context) {
 
 	userExists, err := h.userService.GetUserById(idParam)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
 	file, err := c.FormFile("avatar")
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		form := user.FormUpdateAvatar{}
+		form.ID = idParam
+		form.Name = userExists.Name
+		form.AvatarFileName = userExists.AvatarFileName
+		form.Error = err
+		render(c, http.StatusUnprocessableEntity, "edit_avatar.html", form)
 		return
 	}
 
@@ -140,7 +174,7 @@ func (h *userHandler) UpdateAvatar(c *gin.Context) {
 
 	err = c.SaveUploadedFile(file, path)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusInternalServerError, "error.html", nil)
 		return
 	}
 
@@ -150,11 +184,15 @@ func (h *userHandler) UpdateAvatar(c *gin.Context) {
 	form.AvatarFileName = path
 	form.Name = userExists.Name
 
-	_, err = h.userService.UpdateAvatarFromForm(form)
+	updatedUser, err := h.userService.UpdateAvatarFromForm(form)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, fmt.Sprintf("/web/users/%d/avatar", idParam))
 		return
 	}
 
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "user", updatedUser.ID, userExists, updatedUser)
+
+	setFlash(c, FLASH_SUCCESS, "Avatar has been updated")
 	c.Redirect(http.StatusFound, "/web/users")
 }
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bekasiberbagi/audit"
+	"bekasiberbagi/volunteer"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type volunteerHandler struct {
+	volunteerService volunteer.Service
+	auditService     audit.Service
+}
+
+func NewVolunteerHandler(volunteerService volunteer.Service, auditService audit.Service) *volunteerHandler {
+	return &volunteerHandler{
+		volunteerService: volunteerService,
+		auditService:     auditService,
+	}
+}
+
+func (h *volunteerHandler) Index(c *gin.Context) {
+	opportunities, err := h.volunteerService.GetAllOpportunities()
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "volunteer_index.html", opportunities)
+}
+
+func (h *volunteerHandler) Show(c *gin.Context) {
+	idParam, _ := strconv.Atoi(c.Param("id"))
+
+	opportunity, err := h.volunteerService.GetOpportunityById(idParam)
+
+	if err != nil {
+		render(c, http.StatusNotFound, "error.html", err.Error())
+		return
+	}
+
+	signups, err := h.volunteerService.GetSignups(opportunity.ID)
+
+	if err != nil {
+		render(c, http.StatusInternalServerError, "error.html", nil)
+		return
+	}
+
+	render(c, http.StatusOK, "volunteer_show.html", gin.H{
+		"opportunity": opportunity,
+		"signups":     signups,
+	})
+}
+
+func (h *volunteerHandler) UpdateAttendance(c *gin.Context) {
+	var form volunteer.FormAttendanceInput
+
+	err := c.ShouldBind(&form)
+	form.ID, _ = strconv.Atoi(c.Param("id"))
+	form.CheckedInByID = currentAdminID(c)
+
+	signupExists, findErr := h.volunteerService.GetSignupById(form.ID)
+
+	if findErr != nil {
+		render(c, http.StatusNotFound, "error.html", findErr.Error())
+		return
+	}
+
+	redirectURL := fmt.Sprintf("/web/volunteers/%d", signupExists.OpportunityID)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, redirectURL)
+		return
+	}
+
+	updatedSignup, err := h.volunteerService.UpdateAttendance(form)
+
+	if err != nil {
+		setFlash(c, FLASH_ERROR, err.Error())
+		c.Redirect(http.StatusFound, redirectURL)
+		return
+	}
+
+	recordAudit(c, h.auditService, audit.ACTION_UPDATE, "volunteer_signup", updatedSignup.ID, signupExists, updatedSignup)
+
+	setFlash(c, FLASH_SUCCESS, fmt.Sprintf("%s has been marked as %s", signupExists.User.Name, updatedSignup.Attendance))
+	c.Redirect(http.StatusFound, redirectURL)
+}
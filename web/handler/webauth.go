@@ -19,7 +19,7 @@ func NewWebAuthHandler(userService user.Service) *webAuthHandler {
 }
 
 func (h *webAuthHandler) LoginForm(c *gin.Context) {
-	c.HTML(http.StatusOK, "login.html", nil)
+	render(c, http.StatusOK, "login.html", nil)
 }
 
 func (h *webAuthHandler) LoginAction(c *gin.Context) {
@@ -27,13 +27,13 @@ func (h *webAuthHandler) LoginAction(c *gin.Context) {
 
 	err := c.ShouldBind(&input)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusUnprocessableEntity, "login.html", gin.H{"Error": err})
 		return
 	}
 
 	user, err := h.userService.WebLogin(input)
 	if err != nil || user.Role != "admin" {
-		c.HTML(http.StatusInternalServerError, "error.html", nil)
+		render(c, http.StatusUnauthorized, "login.html", gin.H{"Error": "Invalid email or password"})
 		return
 	}
 
@@ -42,7 +42,7 @@ func (h *webAuthHandler) LoginAction(c *gin.Context) {
 	session.Set("userName", user.Name)
 	session.Save()
 
-	c.Redirect(http.StatusFound, "/web/users")
+	c.Redirect(http.StatusFound, "/web/dashboard")
 }
 
 func (h *webAuthHandler) Logout(c *gin.Context) {
@@ -0,0 +1,68 @@
+package zakat
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DEFAULT_NISAB_GOLD_GRAMS is the nisab of zakat maal, the value of 85 grams
+// of gold.
+const DEFAULT_NISAB_GOLD_GRAMS = 85
+
+// RATE is the 2.5% due on wealth above the nisab.
+const RATE = 0.025
+
+type Config struct {
+	GoldPricePerGram int
+	NisabGoldGrams   float64
+}
+
+// Nisab is the least wealth zakat maal is due on, in rupiah.
+func (c Config) Nisab() int {
+	return int(c.NisabGoldGrams * float64(c.GoldPricePerGram))
+}
+
+// ParseConfig reads the gold price in rupiah per gram and the nisab in grams
+// of gold. The nisab falls back to DEFAULT_NISAB_GOLD_GRAMS. Without a gold
+// price the calculator is unavailable.
+func ParseConfig(goldPricePerGram string, nisabGoldGrams string) (Config, error) {
+	config := Config{NisabGoldGrams: DEFAULT_NISAB_GOLD_GRAMS}
+
+	if strings.TrimSpace(goldPricePerGram) != "" {
+		price, err := strconv.Atoi(strings.TrimSpace(goldPricePerGram))
+		if err != nil || price < 0 {
+			return config, errors.New("INVALID ZAKAT GOLD PRICE " + goldPricePerGram)
+		}
+
+		config.GoldPricePerGram = price
+	}
+
+	if strings.TrimSpace(nisabGoldGrams) != "" {
+		grams, err := strconv.ParseFloat(strings.TrimSpace(nisabGoldGrams), 64)
+		if err != nil || grams <= 0 {
+			return config, errors.New("INVALID ZAKAT NISAB " + nisabGoldGrams)
+		}
+
+		config.NisabGoldGrams = grams
+	}
+
+	return config, nil
+}
+
+type Calculation struct {
+	Cash             int
+	Savings          int
+	GoldValue        int
+	Investments      int
+	BusinessAssets   int
+	Receivables      int
+	TotalAssets      int
+	Debts            int
+	NetAssets        int
+	GoldPricePerGram int
+	NisabGoldGrams   float64
+	Nisab            int
+	IsObligatory     bool
+	ZakatAmount      int
+}
@@ -0,0 +1,38 @@
+package zakat
+
+type CalculationFormatter struct {
+	Cash             int     `json:"cash"`
+	Savings          int     `json:"savings"`
+	GoldValue        int     `json:"gold_value"`
+	Investments      int     `json:"investments"`
+	BusinessAssets   int     `json:"business_assets"`
+	Receivables      int     `json:"receivables"`
+	TotalAssets      int     `json:"total_assets"`
+	Debts            int     `json:"debts"`
+	NetAssets        int     `json:"net_assets"`
+	GoldPricePerGram int     `json:"gold_price_per_gram"`
+	NisabGoldGrams   float64 `json:"nisab_gold_grams"`
+	Nisab            int     `json:"nisab"`
+	IsObligatory     bool    `json:"is_obligatory"`
+	ZakatAmount      int     `json:"zakat_amount"`
+}
+
+func FormatCalculation(calculation Calculation) CalculationFormatter {
+	formatter := CalculationFormatter{}
+	formatter.Cash = calculation.Cash
+	formatter.Savings = calculation.Savings
+	formatter.GoldValue = calculation.GoldValue
+	formatter.Investments = calculation.Investments
+	formatter.BusinessAssets = calculation.BusinessAssets
+	formatter.Receivables = calculation.Receivables
+	formatter.TotalAssets = calculation.TotalAssets
+	formatter.Debts = calculation.Debts
+	formatter.NetAssets = calculation.NetAssets
+	formatter.GoldPricePerGram = calculation.GoldPricePerGram
+	formatter.NisabGoldGrams = calculation.NisabGoldGrams
+	formatter.Nisab = calculation.Nisab
+	formatter.IsObligatory = calculation.IsObligatory
+	formatter.ZakatAmount = calculation.ZakatAmount
+
+	return formatter
+}
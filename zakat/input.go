@@ -0,0 +1,13 @@
+package zakat
+
+// GoldGrams is valued at the configured gold price. Debts are only those due
+// now, they are taken off the assets before comparing with the nisab.
+type CalculateInput struct {
+	Cash           int     `json:"cash" binding:"gte=0"`
+	Savings        int     `json:"savings" binding:"gte=0"`
+	GoldGrams      float64 `json:"gold_grams" binding:"gte=0"`
+	Investments    int     `json:"investments" binding:"gte=0"`
+	BusinessAssets int     `json:"business_assets" binding:"gte=0"`
+	Receivables    int     `json:"receivables" binding:"gte=0"`
+	Debts          int     `json:"debts" binding:"gte=0"`
+}
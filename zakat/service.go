@@ -0,0 +1,45 @@
+package zakat
+
+import (
+	"errors"
+	"math"
+)
+
+type Service interface {
+	Calculate(input CalculateInput) (Calculation, error)
+}
+
+type service struct {
+	config Config
+}
+
+func NewService(config Config) *service {
+	return &service{config}
+}
+
+func (s *service) Calculate(input CalculateInput) (Calculation, error) {
+	if s.config.GoldPricePerGram == 0 {
+		return Calculation{}, errors.New("ZAKAT NISAB IS NOT CONFIGURED")
+	}
+
+	calculation := Calculation{}
+	calculation.Cash = input.Cash
+	calculation.Savings = input.Savings
+	calculation.GoldValue = int(input.GoldGrams * float64(s.config.GoldPricePerGram))
+	calculation.Investments = input.Investments
+	calculation.BusinessAssets = input.BusinessAssets
+	calculation.Receivables = input.Receivables
+	calculation.TotalAssets = calculation.Cash + calculation.Savings + calculation.GoldValue + calculation.Investments + calculation.BusinessAssets + calculation.Receivables
+	calculation.Debts = input.Debts
+	calculation.NetAssets = calculation.TotalAssets - calculation.Debts
+	calculation.GoldPricePerGram = s.config.GoldPricePerGram
+	calculation.NisabGoldGrams = s.config.NisabGoldGrams
+	calculation.Nisab = s.config.Nisab()
+	calculation.IsObligatory = calculation.NetAssets >= calculation.Nisab
+
+	if calculation.IsObligatory {
+		calculation.ZakatAmount = int(math.Ceil(float64(calculation.NetAssets) * RATE))
+	}
+
+	return calculation, nil
+}